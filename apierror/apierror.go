@@ -0,0 +1,49 @@
+package apierror
+
+import (
+	"cache/requestid"
+	"encoding/json"
+	"net/http"
+)
+
+// 응답 body 의 code 값. 클라이언트는 message 대신 code 로 분기한다
+const (
+	CodeInvalidRequest   = "invalid_request"
+	CodeUnauthorized     = "unauthorized"
+	CodeForbidden        = "forbidden"
+	CodeNotFound         = "not_found"       // 캐시 미스 등 요청한 대상이 없음
+	CodeRouteNotFound    = "route_not_found" // 경로 자체가 없음
+	CodeMethodNotAllowed = "method_not_allowed"
+	CodePayloadTooLarge  = "payload_too_large"
+	CodeRateLimited      = "rate_limited"
+	CodeQuotaExceeded    = "quota_exceeded"
+	CodeInternal         = "internal"
+	CodeUnavailable      = "unavailable"
+)
+
+// Body 모든 HTTP 에러 응답의 JSON 형식
+type Body struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// Write status 와 함께 JSON 에러 body 를 쓴다. request ID 는 ctx 에서 가져온다
+func Write(w http.ResponseWriter, r *http.Request, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(Body{Code: code, Message: message, RequestID: requestid.From(r.Context())}); err != nil {
+		return
+	}
+}
+
+// NotFound chi 의 NotFound handler 용
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	Write(w, r, http.StatusNotFound, CodeRouteNotFound, "route not found")
+}
+
+// MethodNotAllowed chi 의 MethodNotAllowed handler 용
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	Write(w, r, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed")
+}
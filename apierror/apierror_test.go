@@ -0,0 +1,61 @@
+package apierror
+
+import (
+	"cache/requestid"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func decode(t *testing.T, w *httptest.ResponseRecorder) Body {
+	t.Helper()
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("Content-Type = %q, want application/json", ct)
+	}
+	var b Body
+	if err := json.NewDecoder(w.Body).Decode(&b); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	return b
+}
+
+func TestWriteIncludesRequestID(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r = r.WithContext(requestid.WithContext(r.Context(), "req-1"))
+	w := httptest.NewRecorder()
+
+	Write(w, r, http.StatusForbidden, CodeForbidden, "forbidden")
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want 403", w.Code)
+	}
+	if b := decode(t, w); b != (Body{Code: CodeForbidden, Message: "forbidden", RequestID: "req-1"}) {
+		t.Fatalf("body = %+v", b)
+	}
+}
+
+func TestRouterHandlersUseDistinctCodes(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus int
+		wantCode   string
+	}{
+		{"not found", NotFound, http.StatusNotFound, CodeRouteNotFound},
+		{"method not allowed", MethodNotAllowed, http.StatusMethodNotAllowed, CodeMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			tt.handler(w, httptest.NewRequest(http.MethodGet, "/nope", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if b := decode(t, w); b.Code != tt.wantCode || b.Code == CodeNotFound {
+				t.Fatalf("code = %q, want %q", b.Code, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"cache/config"
+	"path"
+)
+
+type aclRule struct {
+	principal   string
+	topics      []string
+	permissions map[Permission]struct{}
+}
+
+// acl principal/topic 패턴 별 권한 목록. 일치하는 규칙 중 하나라도 허용하면 통과
+type acl []aclRule
+
+func newACL(rules []config.ACLRule) acl {
+	a := make(acl, 0, len(rules))
+	for _, r := range rules {
+		rule := aclRule{principal: r.Principal, topics: r.Topics, permissions: make(map[Permission]struct{})}
+		for _, p := range r.Permissions {
+			rule.permissions[Permission(p)] = struct{}{}
+		}
+		a = append(a, rule)
+	}
+	return a
+}
+
+func (a acl) allows(subject string, perm Permission, topic string) bool {
+	for _, rule := range a {
+		if rule.principal != "*" && rule.principal != subject {
+			continue
+		}
+		if _, ok := rule.permissions[perm]; !ok {
+			continue
+		}
+		for _, pattern := range rule.topics {
+			if ok, _ := path.Match(pattern, topic); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"cache/config"
+	"testing"
+)
+
+func TestACLAllows(t *testing.T) {
+	a := newACL([]config.ACLRule{
+		{Principal: "users-svc", Topics: []string{"users", "users-*"}, Permissions: []string{"read", "write", "invalidate"}},
+		{Principal: "*", Topics: []string{"public-*"}, Permissions: []string{"read"}},
+		{Principal: "ops", Topics: []string{"*"}, Permissions: []string{"admin"}},
+	})
+
+	tests := []struct {
+		subject string
+		perm    Permission
+		topic   string
+		want    bool
+	}{
+		{"users-svc", PermWrite, "users", true},
+		{"users-svc", PermInvalidate, "users-profile", true},
+		{"users-svc", PermWrite, "orders", false},
+		{"users-svc", PermAdmin, "users", false},
+		{"orders-svc", PermRead, "public-config", true},
+		{"orders-svc", PermWrite, "public-config", false},
+		{"ops", PermAdmin, "*", true},
+		{"ops", PermRead, "users", false},
+		{"", PermRead, "users", false},
+	}
+	for _, tt := range tests {
+		if got := a.allows(tt.subject, tt.perm, tt.topic); got != tt.want {
+			t.Errorf("allows(%q, %s, %q) = %v, want %v", tt.subject, tt.perm, tt.topic, got, tt.want)
+		}
+	}
+}
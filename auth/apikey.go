@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"cache/config"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+type apiKeyAuth struct {
+	keys map[[sha256.Size]byte]string // sha256(key) -> principal
+}
+
+// NewAPIKeyAuthenticator X-API-Key 헤더의 정적 key 로 인증
+func NewAPIKeyAuthenticator(cfg []config.APIKeyConfig) (Authenticator, error) {
+	a := &apiKeyAuth{keys: make(map[[sha256.Size]byte]string, len(cfg))}
+	for _, k := range cfg {
+		key, err := secretValue(k.Key, k.KeyEnv)
+		if err != nil {
+			return nil, fmt.Errorf("api key %q: %w", k.ID, err)
+		}
+		a.keys[sha256.Sum256([]byte(key))] = k.ID
+	}
+	return a, nil
+}
+
+func (a *apiKeyAuth) Authenticate(r *http.Request) (Principal, error) {
+	key := r.Header.Get(apiKeyHeader)
+	if key == "" {
+		return Principal{}, ErrNoCredentials
+	}
+	// 해시끼리 상수 시간 비교해 key 길이/내용이 타이밍으로 새지 않도록 한다
+	sum := sha256.Sum256([]byte(key))
+	for h, id := range a.keys {
+		if subtle.ConstantTimeCompare(h[:], sum[:]) == 1 {
+			return Principal{Subject: id, Method: "apikey"}, nil
+		}
+	}
+	return Principal{}, ErrInvalidCredentials
+}
+
+// secretValue 설정값이 있으면 그대로, 없으면 환경변수에서 읽는다
+func secretValue(value, env string) (string, error) {
+	if value != "" {
+		return value, nil
+	}
+	if env == "" {
+		return "", fmt.Errorf("no value configured")
+	}
+	v := os.Getenv(env)
+	if v == "" {
+		return "", fmt.Errorf("environment variable %s is empty", env)
+	}
+	return v, nil
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"cache/config"
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIKeyAuthenticate(t *testing.T) {
+	t.Setenv("TEST_ORDERS_KEY", "orders-secret")
+	a, err := NewAPIKeyAuthenticator([]config.APIKeyConfig{
+		{ID: "users-svc", Key: "users-secret"},
+		{ID: "orders-svc", KeyEnv: "TEST_ORDERS_KEY"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		subject string
+		err     error
+	}{
+		{name: "inline key", key: "users-secret", subject: "users-svc"},
+		{name: "key from env", key: "orders-secret", subject: "orders-svc"},
+		{name: "unknown key", key: "nope", err: ErrInvalidCredentials},
+		{name: "no header", err: ErrNoCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/v1/cache/users/1", nil)
+			if tt.key != "" {
+				r.Header.Set("X-API-Key", tt.key)
+			}
+			p, err := a.Authenticate(r)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if p.Subject != tt.subject {
+				t.Fatalf("subject = %q, want %q", p.Subject, tt.subject)
+			}
+			if tt.err == nil && p.Method != "apikey" {
+				t.Fatalf("method = %q", p.Method)
+			}
+		})
+	}
+}
+
+func TestAPIKeyRequiresValue(t *testing.T) {
+	// key 도 key_env 도 비어 있으면 시작 시 거부
+	if _, err := NewAPIKeyAuthenticator([]config.APIKeyConfig{{ID: "svc"}}); err == nil {
+		t.Fatal("expected error for key without value")
+	}
+	if _, err := NewAPIKeyAuthenticator([]config.APIKeyConfig{{ID: "svc", KeyEnv: "TEST_UNSET_KEY"}}); err == nil {
+		t.Fatal("expected error for empty environment variable")
+	}
+}
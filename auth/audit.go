@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"cache/logger"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+type auditRecord struct {
+	Time       time.Time `json:"time"`
+	Remote     string    `json:"remote"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Principal  string    `json:"principal,omitempty"`
+	AuthMethod string    `json:"auth_method,omitempty"`
+	Permission string    `json:"permission,omitempty"`
+	Topic      string    `json:"topic,omitempty"`
+	Reason     string    `json:"reason"`
+}
+
+// auditLog 거부된 요청을 JSON lines 로 기록
+type auditLog struct {
+	mu  sync.Mutex
+	out io.Writer
+	c   io.Closer
+}
+
+// newAuditLog file 이 비어 있으면 stdout 에 기록
+func newAuditLog(file string) (*auditLog, error) {
+	if file == "" {
+		return &auditLog{out: os.Stdout}, nil
+	}
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	return &auditLog{out: f, c: f}, nil
+}
+
+func (a *auditLog) deny(r *http.Request, p Principal, perm string, topic string, reason string) {
+	rec := auditRecord{
+		Time:       time.Now().UTC(),
+		Remote:     r.RemoteAddr,
+		Method:     r.Method,
+		Path:       r.URL.Path,
+		Principal:  p.Subject,
+		AuthMethod: p.Method,
+		Permission: perm,
+		Topic:      topic,
+		Reason:     reason,
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.out.Write(data); err != nil {
+		logger.Logger.Errorf("❌ Failed to write audit log: %v", err)
+	}
+}
+
+func (a *auditLog) close() error {
+	if a.c == nil {
+		return nil
+	}
+	return a.c.Close()
+}
@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Permission topic 단위로 부여되는 권한
+type Permission string
+
+const (
+	PermRead       Permission = "read"
+	PermWrite      Permission = "write"
+	PermInvalidate Permission = "invalidate"
+	PermAdmin      Permission = "admin" // DLQ 등 운영용 API
+)
+
+var (
+	// ErrNoCredentials 해당 방식의 인증 정보가 요청에 없음. 다음 Authenticator 로 넘어간다
+	ErrNoCredentials = errors.New("no credentials")
+	// ErrInvalidCredentials 인증 정보가 있지만 검증에 실패함
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// Principal 인증된 호출자
+type Principal struct {
+	Subject string // ACL 의 principal 과 비교하는 이름
+	Method  string // apikey, hmac, jwt
+}
+
+// Authenticator 요청에서 principal 을 식별한다.
+// 자신의 인증 정보가 없으면 ErrNoCredentials 를 반환해야 한다
+type Authenticator interface {
+	Authenticate(r *http.Request) (Principal, error)
+}
+
+type ctxKey struct{}
+
+type authContext struct {
+	principal Principal
+	guard     *Guard
+	req       *http.Request // 거부를 audit log 에 남길 때 쓰는 요청
+}
+
+func withContext(ctx context.Context, p Principal, g *Guard, r *http.Request) context.Context {
+	return context.WithValue(ctx, ctxKey{}, authContext{principal: p, guard: g, req: r})
+}
+
+// PrincipalFrom 미들웨어가 인증한 principal. 인증이 꺼져 있으면 false
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	ac, ok := ctx.Value(ctxKey{}).(authContext)
+	return ac.principal, ok
+}
+
+// Allowed 요청의 principal 이 topic 에 perm 권한을 갖는지 확인. 거부는 audit log 에 남긴다.
+// 인증 미들웨어를 거치지 않은 요청(인증 비활성화)은 항상 허용
+func Allowed(r *http.Request, perm Permission, topic string) bool {
+	ac, ok := r.Context().Value(ctxKey{}).(authContext)
+	if !ok {
+		return true
+	}
+	if ac.guard.acl.allows(ac.principal.Subject, perm, topic) {
+		return true
+	}
+	ac.guard.audit.deny(r, ac.principal, string(perm), topic, "forbidden")
+	return false
+}
+
+// AllowedContext http.Request 없이 들어온 호출(gRPC)용 Allowed
+func AllowedContext(ctx context.Context, perm Permission, topic string) bool {
+	ac, ok := ctx.Value(ctxKey{}).(authContext)
+	if !ok {
+		return true
+	}
+	if ac.guard.acl.allows(ac.principal.Subject, perm, topic) {
+		return true
+	}
+	ac.guard.audit.deny(ac.req, ac.principal, string(perm), topic, "forbidden")
+	return false
+}
+
+// Can Allowed 와 같지만 audit log 를 남기지 않는다. 스트림 이벤트 필터링용
+func Can(ctx context.Context, perm Permission, topic string) bool {
+	ac, ok := ctx.Value(ctxKey{}).(authContext)
+	if !ok {
+		return true
+	}
+	return ac.guard.acl.allows(ac.principal.Subject, perm, topic)
+}
@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"cache/apierror"
+	"cache/config"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Guard 설정된 Authenticator 들로 요청을 인증하고 ACL 로 topic 권한을 판단
+type Guard struct {
+	authenticators []Authenticator
+	acl            acl
+	audit          *auditLog
+}
+
+// NewGuard 설정으로 Guard 생성. extra 로 직접 구현한 Authenticator 를 뒤에 덧붙일 수 있다.
+// JWKS 갱신은 ctx 가 끝날 때까지 계속된다
+func NewGuard(ctx context.Context, cfg config.AuthConfig, extra ...Authenticator) (*Guard, error) {
+	var authenticators []Authenticator
+	if len(cfg.APIKeys) > 0 {
+		a, err := NewAPIKeyAuthenticator(cfg.APIKeys)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, a)
+	}
+	if len(cfg.HMAC.Keys) > 0 {
+		a, err := NewHMACAuthenticator(cfg.HMAC)
+		if err != nil {
+			return nil, err
+		}
+		authenticators = append(authenticators, a)
+	}
+	if cfg.JWT.Enabled {
+		a, err := NewJWTAuthenticator(ctx, cfg.JWT)
+		if err != nil {
+			return nil, fmt.Errorf("jwt: %w", err)
+		}
+		authenticators = append(authenticators, a)
+	}
+
+	authenticators = append(authenticators, extra...)
+
+	audit, err := newAuditLog(cfg.Audit.File)
+	if err != nil {
+		return nil, err
+	}
+	return &Guard{authenticators: authenticators, acl: newACL(cfg.ACL), audit: audit}, nil
+}
+
+// Middleware 인증 실패 시 401. 통과한 요청은 context 에 principal 을 담아 handler 의 Allowed 검사에 쓴다
+func (g *Guard) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, err := g.authenticate(r)
+		if err != nil {
+			reason := "unauthenticated"
+			if !errors.Is(err, ErrNoCredentials) {
+				reason = err.Error()
+			}
+			g.audit.deny(r, Principal{}, "", "", reason)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="cache"`)
+			apierror.Write(w, r, http.StatusUnauthorized, apierror.CodeUnauthorized, "unauthorized")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(withContext(r.Context(), p, g, r)))
+	})
+}
+
+// AuthenticateHeader http.Request 없이 들어온 호출(gRPC)을 header 로 인증하고 principal 을 담은 ctx 를 반환.
+// HMAC 서명은 HTTP method/URI/body 를 대상으로 하므로 여기서는 받지 않는다
+func (g *Guard) AuthenticateHeader(ctx context.Context, remote string, method string, header http.Header) (context.Context, error) {
+	header = header.Clone()
+	header.Del(hmacKeyHeader)
+	header.Del(hmacSignatureHeader)
+	header.Del(hmacTimestampHeader)
+	r := (&http.Request{
+		Method:     http.MethodPost,
+		URL:        &url.URL{Path: method},
+		RequestURI: method,
+		Header:     header,
+		RemoteAddr: remote,
+	}).WithContext(ctx)
+
+	p, err := g.authenticate(r)
+	if err != nil {
+		reason := "unauthenticated"
+		if !errors.Is(err, ErrNoCredentials) {
+			reason = err.Error()
+		}
+		g.audit.deny(r, Principal{}, "", "", reason)
+		return nil, err
+	}
+	return withContext(ctx, p, g, r), nil
+}
+
+// Require topic 과 무관한 route 에 perm 권한을 요구하는 미들웨어 (topic "*" 로 검사)
+func (g *Guard) Require(perm Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !Allowed(r, perm, "*") {
+				apierror.Write(w, r, http.StatusForbidden, apierror.CodeForbidden, "forbidden")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (g *Guard) authenticate(r *http.Request) (Principal, error) {
+	for _, a := range g.authenticators {
+		p, err := a.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return p, err
+	}
+	return Principal{}, ErrNoCredentials
+}
+
+// Close audit log 파일을 닫는다
+func (g *Guard) Close() error {
+	return g.audit.close()
+}
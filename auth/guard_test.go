@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"cache/config"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestGuard(t *testing.T, auditFile string) *Guard {
+	t.Helper()
+	g, err := NewGuard(context.Background(), config.AuthConfig{
+		Enabled: true,
+		APIKeys: []config.APIKeyConfig{
+			{ID: "users-svc", Key: "users-key"},
+			{ID: "ops", Key: "ops-key"},
+		},
+		ACL: []config.ACLRule{
+			{Principal: "users-svc", Topics: []string{"users"}, Permissions: []string{"read"}},
+			{Principal: "ops", Topics: []string{"*"}, Permissions: []string{"admin"}},
+		},
+		Audit: config.AuditConfig{File: auditFile},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = g.Close() })
+	return g
+}
+
+func readAudit(t *testing.T, file string) []auditRecord {
+	t.Helper()
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out []auditRecord
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec auditRecord
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("audit line %q: %v", line, err)
+		}
+		out = append(out, rec)
+	}
+	return out
+}
+
+func TestMiddlewareRejectsUnauthenticated(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "audit.log")
+	g := newTestGuard(t, file)
+	h := g.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler called without credentials")
+	}))
+
+	for _, key := range []string{"", "wrong"} {
+		r := httptest.NewRequest("GET", "/v1/cache/users/1", nil)
+		if key != "" {
+			r.Header.Set("X-API-Key", key)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != http.StatusUnauthorized {
+			t.Fatalf("key %q: status = %d, want 401", key, w.Code)
+		}
+		if w.Header().Get("WWW-Authenticate") == "" {
+			t.Fatalf("key %q: missing WWW-Authenticate", key)
+		}
+	}
+
+	recs := readAudit(t, file)
+	if len(recs) != 2 || recs[0].Reason != "unauthenticated" || recs[0].Path != "/v1/cache/users/1" {
+		t.Fatalf("audit = %+v", recs)
+	}
+}
+
+func TestMiddlewareAllowedByACL(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "audit.log")
+	g := newTestGuard(t, file)
+
+	var allowed map[string]bool
+	h := g.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, ok := PrincipalFrom(r.Context())
+		if !ok || p.Subject != "users-svc" {
+			t.Errorf("principal = %+v, %v", p, ok)
+		}
+		allowed = map[string]bool{
+			"read users":   Allowed(r, PermRead, "users"),
+			"write users":  Allowed(r, PermWrite, "users"),
+			"read orders":  Can(r.Context(), PermRead, "orders"),
+			"read users 2": AllowedContext(r.Context(), PermRead, "users"),
+		}
+	}))
+	r := httptest.NewRequest("GET", "/v1/cache/users/1", nil)
+	r.Header.Set("X-API-Key", "users-key")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+
+	want := map[string]bool{"read users": true, "write users": false, "read orders": false, "read users 2": true}
+	for k, v := range want {
+		if allowed[k] != v {
+			t.Errorf("%s = %v, want %v", k, allowed[k], v)
+		}
+	}
+	// Allowed 의 거부만 기록되고 Can 은 기록하지 않는다
+	recs := readAudit(t, file)
+	if len(recs) != 1 || recs[0].Principal != "users-svc" || recs[0].Permission != "write" || recs[0].Topic != "users" {
+		t.Fatalf("audit = %+v", recs)
+	}
+}
+
+func TestAllowedWithoutGuard(t *testing.T) {
+	// 인증이 꺼져 있으면 모두 허용
+	r := httptest.NewRequest("GET", "/v1/cache/users/1", nil)
+	if !Allowed(r, PermAdmin, "*") || !Can(context.Background(), PermWrite, "users") {
+		t.Fatal("expected allow without guard")
+	}
+	if _, ok := PrincipalFrom(r.Context()); ok {
+		t.Fatal("principal present without guard")
+	}
+}
+
+func TestRequireAdmin(t *testing.T) {
+	g := newTestGuard(t, filepath.Join(t.TempDir(), "audit.log"))
+	h := g.Middleware(g.Require(PermAdmin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})))
+
+	tests := []struct {
+		key  string
+		want int
+	}{
+		{"ops-key", http.StatusNoContent},
+		{"users-key", http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/admin/dlq", nil)
+		r.Header.Set("X-API-Key", tt.key)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != tt.want {
+			t.Errorf("key %q: status = %d, want %d", tt.key, w.Code, tt.want)
+		}
+	}
+}
+
+func TestAuthenticateHeader(t *testing.T) {
+	g, err := NewGuard(context.Background(), config.AuthConfig{
+		APIKeys: []config.APIKeyConfig{{ID: "users-svc", Key: "users-key"}},
+		HMAC:    config.HMACConfig{Keys: []config.HMACKeyConfig{{ID: "batch-job", Secret: "s3cret"}}},
+		Audit:   config.AuditConfig{File: filepath.Join(t.TempDir(), "audit.log")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer g.Close()
+
+	ctx, err := g.AuthenticateHeader(context.Background(), "10.0.0.1:5000", "/cache.Cache/Get", http.Header{"X-Api-Key": {"users-key"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := PrincipalFrom(ctx); p.Subject != "users-svc" {
+		t.Fatalf("principal = %+v", p)
+	}
+
+	// gRPC 에는 서명 대상 body 가 없으므로 HMAC 헤더는 무시된다
+	hmacOnly := http.Header{"X-Auth-Key": {"batch-job"}, "X-Auth-Timestamp": {"1"}, "X-Auth-Signature": {"00"}}
+	if _, err := g.AuthenticateHeader(context.Background(), "10.0.0.1:5000", "/cache.Cache/Get", hmacOnly); err != ErrNoCredentials {
+		t.Fatalf("err = %v, want ErrNoCredentials", err)
+	}
+}
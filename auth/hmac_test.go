@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"cache/config"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestHMAC(t *testing.T, now time.Time) *hmacAuth {
+	t.Helper()
+	a, err := NewHMACAuthenticator(config.HMACConfig{
+		Keys:           []config.HMACKeyConfig{{ID: "batch-job", Secret: "s3cret"}},
+		MaxSkewSeconds: 60,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := a.(*hmacAuth)
+	h.now = func() time.Time { return now }
+	return h
+}
+
+// signedRequest secret 으로 서명한 요청. 서명 시각은 ts
+func signedRequest(method, uri, body, id, secret string, ts time.Time) *http.Request {
+	r := httptest.NewRequest(method, uri, strings.NewReader(body))
+	stamp := strconv.FormatInt(ts.Unix(), 10)
+	r.Header.Set("X-Auth-Key", id)
+	r.Header.Set("X-Auth-Timestamp", stamp)
+	r.Header.Set("X-Auth-Signature", Sign([]byte(secret), SigningString(method, uri, stamp, []byte(body))))
+	return r
+}
+
+func TestHMACAuthenticate(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	a := newTestHMAC(t, now)
+
+	r := signedRequest("PUT", "/v1/cache/users/1?ttl=60", `{"value":"a"}`, "batch-job", "s3cret", now)
+	p, err := a.Authenticate(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Subject != "batch-job" || p.Method != "hmac" {
+		t.Fatalf("principal = %+v", p)
+	}
+	// handler 가 body 를 다시 읽을 수 있어야 한다
+	body, _ := io.ReadAll(r.Body)
+	if string(body) != `{"value":"a"}` {
+		t.Fatalf("body after auth = %q", body)
+	}
+}
+
+func TestHMACRejects(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	a := newTestHMAC(t, now)
+
+	tampered := signedRequest("PUT", "/v1/cache/users/1", `{"value":"a"}`, "batch-job", "s3cret", now)
+	tampered.Body = io.NopCloser(strings.NewReader(`{"value":"b"}`))
+
+	tests := []struct {
+		name string
+		req  *http.Request
+		err  error
+	}{
+		{name: "no headers", req: httptest.NewRequest("GET", "/v1/cache/users/1", nil), err: ErrNoCredentials},
+		{name: "unknown key", req: signedRequest("GET", "/v1/cache/users/1", "", "other", "s3cret", now), err: ErrInvalidCredentials},
+		{name: "wrong secret", req: signedRequest("GET", "/v1/cache/users/1", "", "batch-job", "wrong", now), err: ErrInvalidCredentials},
+		{name: "body changed", req: tampered, err: ErrInvalidCredentials},
+		{name: "too old", req: signedRequest("GET", "/v1/cache/users/1", "", "batch-job", "s3cret", now.Add(-2*time.Minute)), err: ErrInvalidCredentials},
+		{name: "too far ahead", req: signedRequest("GET", "/v1/cache/users/1", "", "batch-job", "s3cret", now.Add(2*time.Minute)), err: ErrInvalidCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := a.Authenticate(tt.req); !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestHMACDefaultSkew(t *testing.T) {
+	a, err := NewHMACAuthenticator(config.HMACConfig{Keys: []config.HMACKeyConfig{{ID: "k", Secret: "s"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := a.(*hmacAuth).maxSkew; got != 5*time.Minute {
+		t.Fatalf("maxSkew = %s, want 5m", got)
+	}
+}
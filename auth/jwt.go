@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"cache/config"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type jwtAuth struct {
+	keys         *jwks
+	parser       *jwt.Parser
+	subjectClaim string
+}
+
+// NewJWTAuthenticator Authorization: Bearer 토큰을 JWKS 공개키로 검증.
+// ctx 가 끝날 때까지 refresh_seconds 마다 JWKS 를 다시 읽는다
+func NewJWTAuthenticator(ctx context.Context, cfg config.JWTConfig) (Authenticator, error) {
+	keys, err := newJWKS(cfg.JWKSFile, cfg.JWKSURL)
+	if err != nil {
+		return nil, err
+	}
+	go keys.watch(ctx, time.Duration(cfg.RefreshSeconds)*time.Second)
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30 * time.Second),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+	subject := cfg.SubjectClaim
+	if subject == "" {
+		subject = "sub"
+	}
+	return &jwtAuth{keys: keys, parser: jwt.NewParser(opts...), subjectClaim: subject}, nil
+}
+
+func (a *jwtAuth) Authenticate(r *http.Request) (Principal, error) {
+	header := r.Header.Get("Authorization")
+	if len(header) < 7 || !strings.EqualFold(header[:7], "Bearer ") {
+		return Principal{}, ErrNoCredentials
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := a.parser.ParseWithClaims(strings.TrimSpace(header[7:]), claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := a.keys.key(kid)
+		if !ok {
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+		return key, nil
+	})
+	if err != nil {
+		return Principal{}, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	subject, _ := claims[a.subjectClaim].(string)
+	if subject == "" {
+		return Principal{}, fmt.Errorf("%w: missing %s claim", ErrInvalidCredentials, a.subjectClaim)
+	}
+	return Principal{Subject: subject, Method: "jwt"}, nil
+}
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"cache/config"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// writeJWKS key 의 공개키를 kid 로 담은 JWKS 파일 경로
+func writeJWKS(t *testing.T, kid string, key *rsa.PrivateKey) string {
+	t.Helper()
+	set := map[string]interface{}{"keys": []map[string]string{{
+		"kid": kid,
+		"kty": "RSA",
+		"use": "sig",
+		"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(file, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func signToken(t *testing.T, kid string, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+	tok := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	tok.Header["kid"] = kid
+	s, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestJWTAuthenticate(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := NewJWTAuthenticator(context.Background(), config.JWTConfig{
+		JWKSFile: writeJWKS(t, "k1", key),
+		Issuer:   "https://issuer.example",
+		Audience: "cache",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub": "users-svc",
+			"iss": "https://issuer.example",
+			"aud": "cache",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+	}
+	with := func(k string, v interface{}) jwt.MapClaims {
+		c := valid()
+		if v == nil {
+			delete(c, k)
+		} else {
+			c[k] = v
+		}
+		return c
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		err   error
+	}{
+		{name: "valid", token: signToken(t, "k1", key, valid())},
+		{name: "expired", token: signToken(t, "k1", key, with("exp", time.Now().Add(-time.Hour).Unix())), err: ErrInvalidCredentials},
+		{name: "no exp", token: signToken(t, "k1", key, with("exp", nil)), err: ErrInvalidCredentials},
+		{name: "wrong issuer", token: signToken(t, "k1", key, with("iss", "https://evil.example")), err: ErrInvalidCredentials},
+		{name: "wrong audience", token: signToken(t, "k1", key, with("aud", "other")), err: ErrInvalidCredentials},
+		{name: "no subject", token: signToken(t, "k1", key, with("sub", nil)), err: ErrInvalidCredentials},
+		{name: "unknown kid", token: signToken(t, "k2", key, valid()), err: ErrInvalidCredentials},
+		{name: "wrong key", token: signToken(t, "k1", other, valid()), err: ErrInvalidCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/v1/cache/users/1", nil)
+			r.Header.Set("Authorization", "Bearer "+tt.token)
+			p, err := a.Authenticate(r)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if tt.err == nil && (p.Subject != "users-svc" || p.Method != "jwt") {
+				t.Fatalf("principal = %+v", p)
+			}
+		})
+	}
+
+	// Bearer 가 아니면 다음 Authenticator 로 넘긴다
+	r := httptest.NewRequest("GET", "/v1/cache/users/1", nil)
+	r.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+	if _, err := a.Authenticate(r); !errors.Is(err, ErrNoCredentials) {
+		t.Fatalf("err = %v, want ErrNoCredentials", err)
+	}
+}
+
+func TestJWTSubjectClaim(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := NewJWTAuthenticator(context.Background(), config.JWTConfig{
+		JWKSFile:     writeJWKS(t, "k1", key),
+		SubjectClaim: "client_id",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest("GET", "/v1/cache/users/1", nil)
+	r.Header.Set("Authorization", "bearer "+signToken(t, "", key, jwt.MapClaims{
+		"client_id": "orders-svc",
+		"exp":       time.Now().Add(time.Hour).Unix(),
+	}))
+	// kid 없는 토큰도 key 가 하나뿐이면 허용
+	p, err := a.Authenticate(r)
+	if err != nil || p.Subject != "orders-svc" {
+		t.Fatalf("principal = %+v, err = %v", p, err)
+	}
+}
+
+func TestJWKSRejectsEmptySet(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(file, []byte(`{"keys":[{"kid":"x","kty":"oct"}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newJWKS(file, ""); err == nil {
+		t.Fatal("expected error for JWKS without usable keys")
+	}
+}
@@ -0,0 +1,124 @@
+package cachekit
+
+import (
+	_interface "cache/interface"
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Cache topic 하나에 대한 타입 지정 캐시
+type Cache[T any] struct {
+	client *Client
+	topic  string
+	codec  Codec[T]
+	ttl    int
+	group  singleflight.Group
+}
+
+type cacheOptions struct {
+	codec any
+	ttl   int
+}
+
+type Option func(*cacheOptions)
+
+// WithCodec 값 직렬화 codec 지정 (기본 JSONCodec)
+func WithCodec[T any](c Codec[T]) Option {
+	return func(o *cacheOptions) { o.codec = c }
+}
+
+// WithTTL Set 에 사용할 기본 TTL(초)
+func WithTTL(ttlSeconds int) Option {
+	return func(o *cacheOptions) { o.ttl = ttlSeconds }
+}
+
+// New client 위에 topic 전용 Cache[T] 생성
+func New[T any](client *Client, topic string, opts ...Option) (*Cache[T], error) {
+	if client == nil {
+		return nil, errors.New("cachekit: client is required")
+	}
+	if topic == "" {
+		return nil, errors.New("cachekit: topic is required")
+	}
+
+	o := &cacheOptions{codec: JSONCodec[T]{}}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	codec, ok := o.codec.(Codec[T])
+	if !ok {
+		return nil, fmt.Errorf("cachekit: codec %T does not match value type", o.codec)
+	}
+
+	return &Cache[T]{
+		client: client,
+		topic:  topic,
+		codec:  codec,
+		ttl:    o.ttl,
+	}, nil
+}
+
+// Get 값을 조회. 캐시 미스면 found 는 false
+func (c *Cache[T]) Get(ctx context.Context, key string) (value T, found bool, err error) {
+	raw, err := c.client.service.Get(ctx, c.topic, key)
+	if errors.Is(err, _interface.ErrNotFound) {
+		return value, false, nil
+	}
+	if err != nil {
+		return value, false, err
+	}
+	value, err = c.codec.Unmarshal([]byte(raw))
+	if err != nil {
+		return value, false, fmt.Errorf("cachekit: decode %s:%s: %w", c.topic, key, err)
+	}
+	return value, true, nil
+}
+
+// Set 기본 TTL 로 값을 저장
+func (c *Cache[T]) Set(ctx context.Context, key string, value T) error {
+	return c.SetWithTTL(ctx, key, value, c.ttl)
+}
+
+// SetWithTTL 지정한 TTL(초)로 값을 저장
+func (c *Cache[T]) SetWithTTL(ctx context.Context, key string, value T, ttlSeconds int) error {
+	data, err := c.codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("cachekit: encode %s:%s: %w", c.topic, key, err)
+	}
+	return c.client.service.Set(ctx, c.topic, key, string(data), ttlSeconds)
+}
+
+// GetOrLoad 캐시 미스 시 loader 결과를 저장 후 반환. 같은 key 의 동시 로드는 하나로 합친다
+func (c *Cache[T]) GetOrLoad(ctx context.Context, key string, loader func(ctx context.Context) (T, error)) (T, error) {
+	if value, found, err := c.Get(ctx, key); err == nil && found {
+		return value, nil
+	}
+
+	v, err, _ := c.group.Do(key, func() (interface{}, error) {
+		value, err := loader(ctx)
+		if err != nil {
+			return value, err
+		}
+		if err := c.Set(ctx, key, value); err != nil {
+			return value, err
+		}
+		return value, nil
+	})
+	value, _ := v.(T)
+	return value, err
+}
+
+// Invalidate 로컬 캐시를 무효화하고 broker 가 있으면 다른 노드에 전파
+func (c *Cache[T]) Invalidate(ctx context.Context, key string) error {
+	if err := c.client.service.Invalidate(ctx, c.topic, key); err != nil {
+		return err
+	}
+	if c.client.broker != nil {
+		return c.client.broker.Publish(ctx, c.topic, key)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package cachekit
+
+import (
+	"cache/config"
+	"cache/core"
+	"cache/core/cache_adapter"
+	"cache/core/strategy"
+	_interface "cache/interface"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// recordingBroker 발행된 topic:key 를 기록
+type recordingBroker struct {
+	mu        sync.Mutex
+	published []string
+}
+
+func (b *recordingBroker) Publish(_ context.Context, topic string, key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.published = append(b.published, topic+":"+key)
+	return nil
+}
+func (b *recordingBroker) Subscribe(context.Context, _interface.MessageHandler) error { return nil }
+func (b *recordingBroker) Ping(context.Context) error                                 { return nil }
+func (b *recordingBroker) ConsumerStatus() _interface.ConsumerStatus {
+	return _interface.ConsumerStatus{}
+}
+func (b *recordingBroker) Close() error { return nil }
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func newTestClient(t *testing.T, broker _interface.IEventBroker) *Client {
+	t.Helper()
+	opts := []ClientOption{
+		WithAdapter(cache_adapter.NewMemoryAdapter(config.MemoryConfig{})),
+		WithStrategy(strategy.NewVersionedKeyStrategy(config.InvalidationConfig{})),
+		WithListener(false),
+	}
+	if broker != nil {
+		opts = append(opts, WithBroker(broker))
+	}
+	c, err := NewClient(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+	return c
+}
+
+func TestCacheRoundTrip(t *testing.T) {
+	users, err := New[user](newTestClient(t, nil), "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	if _, found, err := users.Get(ctx, "1"); err != nil || found {
+		t.Fatalf("Get before Set = found %v, %v", found, err)
+	}
+	if err := users.Set(ctx, "1", user{ID: 1, Name: "alice"}); err != nil {
+		t.Fatal(err)
+	}
+	got, found, err := users.Get(ctx, "1")
+	if err != nil || !found || got != (user{ID: 1, Name: "alice"}) {
+		t.Fatalf("Get = %+v, %v, %v", got, found, err)
+	}
+}
+
+func TestInvalidatePublishes(t *testing.T) {
+	broker := &recordingBroker{}
+	users, err := New[user](newTestClient(t, broker), "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := users.Set(ctx, "1", user{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := users.Invalidate(ctx, "1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, found, _ := users.Get(ctx, "1"); found {
+		t.Fatal("value still cached after Invalidate")
+	}
+	if len(broker.published) != 1 || broker.published[0] != "users:1" {
+		t.Fatalf("published = %v", broker.published)
+	}
+}
+
+func TestGetOrLoadCoalescesConcurrentLoads(t *testing.T) {
+	users, err := New[user](newTestClient(t, nil), "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loads atomic.Int32
+	release := make(chan struct{})
+	loader := func(context.Context) (user, error) {
+		loads.Add(1)
+		<-release
+		return user{ID: 7}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := users.GetOrLoad(context.Background(), "7", loader); err != nil || v.ID != 7 {
+				t.Errorf("GetOrLoad = %+v, %v", v, err)
+			}
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := loads.Load(); n != 1 {
+		t.Fatalf("loader called %d times, want 1", n)
+	}
+	if _, found, _ := users.Get(context.Background(), "7"); !found {
+		t.Fatal("loaded value was not stored")
+	}
+}
+
+func TestGetOrLoadDoesNotStoreErrors(t *testing.T) {
+	users, err := New[user](newTestClient(t, nil), "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	boom := errors.New("boom")
+	if _, err := users.GetOrLoad(context.Background(), "1", func(context.Context) (user, error) { return user{}, boom }); !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want %v", err, boom)
+	}
+	if _, found, _ := users.Get(context.Background(), "1"); found {
+		t.Fatal("failed load was stored")
+	}
+}
+
+func TestCodecs(t *testing.T) {
+	client := newTestClient(t, nil)
+	ctx := context.Background()
+
+	gobCache, err := New[user](client, "gob", WithCodec[user](GobCodec[user]{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := gobCache.Set(ctx, "1", user{ID: 1, Name: "bob"}); err != nil {
+		t.Fatal(err)
+	}
+	if v, _, err := gobCache.Get(ctx, "1"); err != nil || v.Name != "bob" {
+		t.Fatalf("gob Get = %+v, %v", v, err)
+	}
+
+	protoCache, err := New[*wrapperspb.StringValue](client, "proto", WithCodec[*wrapperspb.StringValue](NewProtoCodec(func() *wrapperspb.StringValue { return &wrapperspb.StringValue{} })))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := protoCache.Set(ctx, "1", wrapperspb.String("carol")); err != nil {
+		t.Fatal(err)
+	}
+	if v, _, err := protoCache.Get(ctx, "1"); err != nil || v.GetValue() != "carol" {
+		t.Fatalf("proto Get = %v, %v", v, err)
+	}
+}
+
+func TestNewRejectsMismatchedCodec(t *testing.T) {
+	if _, err := New[user](newTestClient(t, nil), "users", WithCodec[string](JSONCodec[string]{})); err == nil {
+		t.Fatal("expected error for codec of another type")
+	}
+	if _, err := New[user](nil, "users"); err == nil {
+		t.Fatal("expected error for nil client")
+	}
+}
+
+func TestNewClientRequiresAdapterAndStrategy(t *testing.T) {
+	if _, err := NewClient(WithListener(false)); err == nil {
+		t.Fatal("expected error without adapter")
+	}
+}
+
+func TestNewClientAppliesConfiguredQuota(t *testing.T) {
+	c, err := NewClient(
+		WithConfig(&config.Config{Cache: config.CacheConfig{
+			Type:  "memory",
+			Quota: config.QuotaConfig{Default: config.TopicQuota{MaxKeys: 1}},
+		}}),
+		WithStrategy(strategy.NewVersionedKeyStrategy(config.InvalidationConfig{})),
+		WithListener(false),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = c.Close() })
+
+	users, err := New[user](c, "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := users.Set(ctx, "1", user{ID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := users.Set(ctx, "2", user{ID: 2}); !errors.Is(err, core.ErrQuotaExceeded) {
+		t.Fatalf("err = %v, want ErrQuotaExceeded", err)
+	}
+}
@@ -0,0 +1,119 @@
+package cachekit
+
+import (
+	"cache/config"
+	"cache/core"
+	_interface "cache/interface"
+	"cache/metrics"
+	"context"
+	"errors"
+)
+
+// Client 여러 Cache[T] 가 공유하는 CacheService 와 broker 묶음
+type Client struct {
+	service *core.CacheService
+	adapter _interface.ICacheAdapter
+	broker  _interface.IEventBroker
+	stop    context.CancelFunc
+}
+
+type clientOptions struct {
+	conf     *config.Config
+	adapter  _interface.ICacheAdapter
+	strategy _interface.IInvalidationStrategy
+	broker   _interface.IEventBroker
+	listen   bool
+}
+
+type ClientOption func(*clientOptions)
+
+// WithConfig config.Config 로부터 adapter, strategy, broker 를 생성하고 cache.quota 를 적용
+func WithConfig(conf *config.Config) ClientOption {
+	return func(o *clientOptions) { o.conf = conf }
+}
+
+// WithAdapter 코드로 생성한 cache adapter 사용. WithConfig 보다 우선
+func WithAdapter(a _interface.ICacheAdapter) ClientOption {
+	return func(o *clientOptions) { o.adapter = a }
+}
+
+// WithStrategy 코드로 생성한 invalidation 전략 사용. WithConfig 보다 우선
+func WithStrategy(s _interface.IInvalidationStrategy) ClientOption {
+	return func(o *clientOptions) { o.strategy = s }
+}
+
+// WithBroker 코드로 생성한 event broker 사용. WithConfig 보다 우선
+func WithBroker(b _interface.IEventBroker) ClientOption {
+	return func(o *clientOptions) { o.broker = b }
+}
+
+// WithListener broker 로부터 다른 노드의 invalidation 을 수신할지 여부 (기본 true)
+func WithListener(enabled bool) ClientOption {
+	return func(o *clientOptions) { o.listen = enabled }
+}
+
+// NewClient 옵션으로 구성한 Client 생성. broker 가 있으면 listener 를 시작
+func NewClient(opts ...ClientOption) (*Client, error) {
+	o := &clientOptions{listen: true}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.conf != nil {
+		metrics.SetTopics(o.conf.MetricTopics())
+		var err error
+		if o.adapter == nil {
+			if o.adapter, err = core.NewCacheAdapter(o.conf.Cache); err != nil {
+				return nil, err
+			}
+		}
+		if o.strategy == nil {
+			if o.strategy, err = core.NewInvalidationStrategy(o.conf.Invalidation); err != nil {
+				return nil, err
+			}
+		}
+		if o.broker == nil && o.conf.EventBroker.Type != "" {
+			if o.broker, err = core.NewEventBroker(o.conf.EventBroker); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if o.adapter == nil {
+		return nil, errors.New("cachekit: cache adapter is required")
+	}
+	if o.strategy == nil {
+		return nil, errors.New("cachekit: invalidation strategy is required")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	service := core.NewCacheService(o.adapter, o.strategy)
+	if o.conf != nil {
+		service.SetQuota(o.conf.Cache.Quota)
+	}
+	if o.broker != nil && o.listen {
+		core.NewEventListener(o.broker, service).Start(ctx)
+	}
+
+	return &Client{service: service, adapter: o.adapter, broker: o.broker, stop: cancel}, nil
+}
+
+// Close listener 를 멈추고 broker 와 cache adapter 를 닫는다
+func (c *Client) Close() error {
+	c.stop()
+	var errs []error
+	if c.broker != nil {
+		if err := c.broker.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := c.adapter.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Service 내부 CacheService 반환
+func (c *Client) Service() *core.CacheService {
+	return c.service
+}
@@ -0,0 +1,64 @@
+package cachekit
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec 타입 T 값과 캐시에 저장되는 바이트 사이의 변환
+type Codec[T any] interface {
+	Marshal(v T) ([]byte, error)
+	Unmarshal(data []byte) (T, error)
+}
+
+// JSONCodec encoding/json 기반 codec
+type JSONCodec[T any] struct{}
+
+func (JSONCodec[T]) Marshal(v T) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSONCodec[T]) Unmarshal(data []byte) (T, error) {
+	var v T
+	err := json.Unmarshal(data, &v)
+	return v, err
+}
+
+// GobCodec encoding/gob 기반 codec
+type GobCodec[T any] struct{}
+
+func (GobCodec[T]) Marshal(v T) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (GobCodec[T]) Unmarshal(data []byte) (T, error) {
+	var v T
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&v)
+	return v, err
+}
+
+// ProtoCodec protobuf 메시지용 codec. newFn 은 디코딩 대상 메시지를 생성
+type ProtoCodec[T proto.Message] struct {
+	newFn func() T
+}
+
+func NewProtoCodec[T proto.Message](newFn func() T) ProtoCodec[T] {
+	return ProtoCodec[T]{newFn: newFn}
+}
+
+func (p ProtoCodec[T]) Marshal(v T) ([]byte, error) {
+	return proto.Marshal(v)
+}
+
+func (p ProtoCodec[T]) Unmarshal(data []byte) (T, error) {
+	v := p.newFn()
+	err := proto.Unmarshal(data, v)
+	return v, err
+}
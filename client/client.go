@@ -0,0 +1,292 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client 캐시 서버 HTTP API 클라이언트
+type Client struct {
+	baseURL    string
+	http       *http.Client
+	maxRetries int
+	backoff    time.Duration
+	maxBackoff time.Duration
+	authorize  func(req *http.Request, body []byte)
+}
+
+type Option func(*Client)
+
+// WithHTTPClient 직접 구성한 http.Client 사용
+func WithHTTPClient(c *http.Client) Option {
+	return func(cl *Client) { cl.http = c }
+}
+
+// WithTimeout 시도 1회당 요청 타임아웃
+func WithTimeout(d time.Duration) Option {
+	return func(cl *Client) { cl.http.Timeout = d }
+}
+
+// WithRetry 최대 재시도 횟수와 지수 backoff 의 시작/최대 간격
+func WithRetry(maxRetries int, backoff time.Duration, maxBackoff time.Duration) Option {
+	return func(cl *Client) {
+		cl.maxRetries = maxRetries
+		cl.backoff = backoff
+		cl.maxBackoff = maxBackoff
+	}
+}
+
+// WithAPIKey X-API-Key 헤더로 인증
+func WithAPIKey(key string) Option {
+	return func(cl *Client) {
+		cl.authorize = func(req *http.Request, _ []byte) { req.Header.Set("X-API-Key", key) }
+	}
+}
+
+// WithBearerToken JWT 등 Bearer 토큰으로 인증
+func WithBearerToken(token string) Option {
+	return func(cl *Client) {
+		cl.authorize = func(req *http.Request, _ []byte) { req.Header.Set("Authorization", "Bearer "+token) }
+	}
+}
+
+// WithHMAC 요청마다 keyID 의 secret 으로 HMAC-SHA256 서명
+func WithHMAC(keyID string, secret []byte) Option {
+	return func(cl *Client) {
+		cl.authorize = func(req *http.Request, body []byte) {
+			ts := strconv.FormatInt(time.Now().Unix(), 10)
+			sum := sha256.Sum256(body)
+			mac := hmac.New(sha256.New, secret)
+			mac.Write([]byte(strings.Join([]string{req.Method, req.URL.RequestURI(), ts, hex.EncodeToString(sum[:])}, "\n")))
+			req.Header.Set("X-Auth-Key", keyID)
+			req.Header.Set("X-Auth-Timestamp", ts)
+			req.Header.Set("X-Auth-Signature", hex.EncodeToString(mac.Sum(nil)))
+		}
+	}
+}
+
+// New baseURL(예: http://localhost:8000) 로 요청하는 Client 생성
+func New(baseURL string, opts ...Option) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = 100
+
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		http:       &http.Client{Transport: transport, Timeout: 5 * time.Second},
+		maxRetries: 3,
+		backoff:    100 * time.Millisecond,
+		maxBackoff: 2 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Get 값을 조회. 미스면 ErrNotFound
+func (c *Client) Get(ctx context.Context, topic string, key string) (string, error) {
+	body, err := c.do(ctx, http.MethodGet, cachePath("/v1/cache", topic, key), nil)
+	if err != nil {
+		return "", err
+	}
+	var resp struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", err
+	}
+	return resp.Value, nil
+}
+
+// Set 값을 저장. ttlSeconds 가 0 이면 서버 기본값
+func (c *Client) Set(ctx context.Context, topic string, key string, value string, ttlSeconds int) error {
+	payload := map[string]interface{}{"value": value, "ttl": ttlSeconds}
+	_, err := c.do(ctx, http.MethodPut, cachePath("/v1/cache", topic, key), payload)
+	return err
+}
+
+// Invalidate 값을 무효화하고 서버가 다른 노드로 전파
+func (c *Client) Invalidate(ctx context.Context, topic string, key string) error {
+	_, err := c.do(ctx, http.MethodDelete, cachePath("/v1/cache", topic, key), nil)
+	return err
+}
+
+// BatchGet 여러 key 를 한 번에 조회
+func (c *Client) BatchGet(ctx context.Context, keys []Key) ([]GetResult, error) {
+	var resp struct {
+		Items []GetResult `json:"items"`
+	}
+	if err := c.doJSON(ctx, "/v1/batch/get", map[string]interface{}{"items": keys}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// BatchSet 여러 값을 한 번에 저장
+func (c *Client) BatchSet(ctx context.Context, items []SetItem) ([]Result, error) {
+	var resp struct {
+		Items []Result `json:"items"`
+	}
+	if err := c.doJSON(ctx, "/v1/batch/set", map[string]interface{}{"items": items}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+// BatchInvalidate 여러 key 를 한 번에 무효화
+func (c *Client) BatchInvalidate(ctx context.Context, keys []Key) ([]Result, error) {
+	var resp struct {
+		Items []Result `json:"items"`
+	}
+	if err := c.doJSON(ctx, "/v1/batch/invalidate", map[string]interface{}{"items": keys}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Items, nil
+}
+
+func (c *Client) doJSON(ctx context.Context, path string, payload interface{}, out interface{}) error {
+	body, err := c.do(ctx, http.MethodPost, path, payload)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, out)
+}
+
+// do 요청을 보내고 재시도 가능한 실패는 지수 backoff + jitter 로 재시도
+func (c *Client) do(ctx context.Context, method string, path string, payload interface{}) ([]byte, error) {
+	var data []byte
+	if payload != nil {
+		var err error
+		if data, err = json.Marshal(payload); err != nil {
+			return nil, err
+		}
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			wait := c.delay(attempt)
+			// 서버가 Retry-After 로 알려준 시각 전에는 다시 보내지 않는다
+			var se *ServerError
+			if errors.As(lastErr, &se) && se.RetryAfter > wait {
+				wait = se.RetryAfter
+			}
+			if err := sleep(ctx, wait); err != nil {
+				return nil, err
+			}
+		}
+
+		body, err := c.send(ctx, method, path, data)
+		if err == nil {
+			return body, nil
+		}
+		lastErr = err
+
+		// 미스와 429 를 뺀 4xx 는 다시 보내도 결과가 같다
+		if errors.Is(err, ErrNotFound) {
+			return nil, err
+		}
+		var se *ServerError
+		if errors.As(err, &se) && !se.retryable() {
+			return nil, err
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+	return nil, lastErr
+}
+
+func (c *Client) send(ctx context.Context, method string, path string, data []byte) ([]byte, error) {
+	var reader io.Reader
+	if data != nil {
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.authorize != nil {
+		c.authorize(req, data)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			// do nothing
+		}
+	}(resp.Body)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		se := newServerError(resp.StatusCode, body)
+		// 경로가 없는 404 는 미스가 아니라 잘못된 baseURL 등 설정 문제다
+		if resp.StatusCode == http.StatusNotFound && se.Code != "route_not_found" {
+			return nil, ErrNotFound
+		}
+		se.RetryAfter = retryAfter(resp.Header.Get("Retry-After"))
+		return nil, se
+	}
+	return body, nil
+}
+
+func (c *Client) delay(attempt int) time.Duration {
+	d := c.backoff << (attempt - 1)
+	if d <= 0 || d > c.maxBackoff {
+		d = c.maxBackoff
+	}
+	// full jitter
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// retryAfter Retry-After 헤더의 초 또는 HTTP 날짜를 대기 시간으로 변환. 없거나 잘못되면 0
+func retryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+func cachePath(prefix string, topic string, key string) string {
+	return prefix + "/" + url.PathEscape(topic) + "/" + url.PathEscape(key)
+}
@@ -0,0 +1,216 @@
+package client
+
+import (
+	"cache/auth"
+	"cache/config"
+	"cache/core"
+	"cache/core/cache_adapter"
+	"cache/core/strategy"
+	"cache/handler"
+	_interface "cache/interface"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type nopBroker struct{}
+
+func (nopBroker) Publish(context.Context, string, string) error              { return nil }
+func (nopBroker) Subscribe(context.Context, _interface.MessageHandler) error { return nil }
+func (nopBroker) Ping(context.Context) error                                 { return nil }
+func (nopBroker) ConsumerStatus() _interface.ConsumerStatus                  { return _interface.ConsumerStatus{} }
+func (nopBroker) Close() error                                               { return nil }
+
+// downAdapter backend 장애를 흉내 내는 adapter
+type downAdapter struct{}
+
+func (downAdapter) Get(context.Context, string) (string, error)    { return "", _interface.ErrUnavailable }
+func (downAdapter) Set(context.Context, string, string, int) error { return _interface.ErrUnavailable }
+func (downAdapter) Invalidate(context.Context, string) error       { return _interface.ErrUnavailable }
+func (downAdapter) Ping(context.Context) error                     { return _interface.ErrUnavailable }
+func (downAdapter) Close() error                                   { return nil }
+
+// testServer handler.NewRouter 를 띄우고 받은 요청 수를 센다. before 가 true 를 반환하면 router 로 넘기지 않는다
+type testServer struct {
+	*httptest.Server
+	requests atomic.Int32
+}
+
+func newTestServer(t *testing.T, adapter _interface.ICacheAdapter, guard *auth.Guard, before func(w http.ResponseWriter, n int32) bool) *testServer {
+	t.Helper()
+	if adapter == nil {
+		adapter = cache_adapter.NewMemoryAdapter(config.MemoryConfig{})
+	}
+	service := core.NewCacheService(adapter, strategy.NewVersionedKeyStrategy(config.InvalidationConfig{}))
+	router := handler.NewRouter(service, nopBroker{}, core.NewEventListener(nil, nil), config.StreamConfig{}, nil, guard, nil, nil, nil)
+
+	ts := &testServer{}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := ts.requests.Add(1)
+		if before != nil && before(w, n) {
+			return
+		}
+		router.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func newTestClient(url string, opts ...Option) *Client {
+	return New(url, append([]Option{WithRetry(2, time.Millisecond, 5*time.Millisecond)}, opts...)...)
+}
+
+func TestSetGetInvalidate(t *testing.T) {
+	ts := newTestServer(t, nil, nil, nil)
+	c := newTestClient(ts.URL)
+	ctx := context.Background()
+
+	if err := c.Set(ctx, "users", "a/b", "alice", 60); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	got, err := c.Get(ctx, "users", "a/b")
+	if err != nil || got != "alice" {
+		t.Fatalf("Get = %q, %v", got, err)
+	}
+	if err := c.Invalidate(ctx, "users", "a/b"); err != nil {
+		t.Fatalf("Invalidate: %v", err)
+	}
+	if _, err := c.Get(ctx, "users", "a/b"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get after Invalidate err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestMissIsNotRetried(t *testing.T) {
+	ts := newTestServer(t, nil, nil, nil)
+	c := newTestClient(ts.URL)
+
+	if _, err := c.Get(context.Background(), "users", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+	if n := ts.requests.Load(); n != 1 {
+		t.Fatalf("requests = %d, want 1", n)
+	}
+}
+
+func TestRetriesUnavailable(t *testing.T) {
+	ts := newTestServer(t, nil, nil, func(w http.ResponseWriter, n int32) bool {
+		if n < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return true
+		}
+		return false
+	})
+	c := newTestClient(ts.URL)
+
+	if err := c.Set(context.Background(), "users", "1", "v", 0); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if n := ts.requests.Load(); n != 3 {
+		t.Fatalf("requests = %d, want 3", n)
+	}
+}
+
+func TestHonoursRetryAfter(t *testing.T) {
+	ts := newTestServer(t, nil, nil, func(w http.ResponseWriter, n int32) bool {
+		if n == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return true
+		}
+		return false
+	})
+	c := newTestClient(ts.URL)
+
+	start := time.Now()
+	if err := c.Set(context.Background(), "users", "1", "v", 0); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("retried after %v, want >= 1s", elapsed)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	guard, err := auth.NewGuard(context.Background(), config.AuthConfig{
+		Enabled: true,
+		APIKeys: []config.APIKeyConfig{{ID: "reader", Key: "secret"}},
+		ACL:     []config.ACLRule{{Principal: "reader", Topics: []string{"users"}, Permissions: []string{"read"}}},
+		Audit:   config.AuditConfig{File: filepath.Join(t.TempDir(), "audit.log")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = guard.Close() })
+
+	tests := []struct {
+		name         string
+		adapter      _interface.ICacheAdapter
+		guard        *auth.Guard
+		opts         []Option
+		wantStatus   int
+		wantCode     string
+		wantRequests int32
+	}{
+		{"unauthorized", nil, guard, nil, http.StatusUnauthorized, "unauthorized", 1},
+		{"forbidden", nil, guard, []Option{WithAPIKey("secret")}, http.StatusForbidden, "forbidden", 1},
+		{"unavailable", downAdapter{}, nil, nil, http.StatusServiceUnavailable, "unavailable", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t, tt.adapter, tt.guard, nil)
+			c := newTestClient(ts.URL, tt.opts...)
+
+			err := c.Set(context.Background(), "users", "1", "v", 0)
+			var se *ServerError
+			if !errors.As(err, &se) {
+				t.Fatalf("err = %v, want *ServerError", err)
+			}
+			if se.StatusCode != tt.wantStatus || se.Code != tt.wantCode || se.RequestID == "" {
+				t.Fatalf("ServerError = %+v, want status %d code %s with request id", se, tt.wantStatus, tt.wantCode)
+			}
+			if n := ts.requests.Load(); n != tt.wantRequests {
+				t.Fatalf("requests = %d, want %d", n, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"", 0},
+		{"3", 3 * time.Second},
+		{"soon", 0},
+		{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), -time.Minute},
+	}
+	for _, tt := range tests {
+		got := retryAfter(tt.header)
+		if tt.want < 0 {
+			if got > 0 {
+				t.Errorf("retryAfter(%q) = %v, want <= 0", tt.header, got)
+			}
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("retryAfter(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestUnknownRouteIsNotAMiss(t *testing.T) {
+	ts := newTestServer(t, nil, nil, nil)
+	c := newTestClient(ts.URL + "/wrong-prefix")
+
+	_, err := c.Get(context.Background(), "users", "1")
+	var se *ServerError
+	if !errors.As(err, &se) || se.Code != "route_not_found" {
+		t.Fatalf("err = %v, want route_not_found ServerError", err)
+	}
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrNotFound 캐시 미스
+var ErrNotFound = errors.New("client: cache miss")
+
+// ServerError 서버가 2xx 가 아닌 응답을 반환한 경우. Code 와 RequestID 는 서버의 JSON 에러 body 에서 읽는다
+type ServerError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestID  string
+	RetryAfter time.Duration // 429/503 의 Retry-After, 없으면 0
+}
+
+func (e *ServerError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("client: server error [status=%d, code=%s, request_id=%s]: %s", e.StatusCode, e.Code, e.RequestID, e.Message)
+	}
+	return fmt.Sprintf("client: server error [status=%d]: %s", e.StatusCode, e.Message)
+}
+
+// newServerError JSON 에러 body 가 아니면 body 전체를 Message 로 사용
+func newServerError(status int, body []byte) *ServerError {
+	var payload struct {
+		Code      string `json:"code"`
+		Message   string `json:"message"`
+		RequestID string `json:"request_id"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Code == "" {
+		return &ServerError{StatusCode: status, Message: strings.TrimSpace(string(body))}
+	}
+	return &ServerError{StatusCode: status, Code: payload.Code, Message: payload.Message, RequestID: payload.RequestID}
+}
+
+// retryable 재시도해도 되는 응답인지
+func (e *ServerError) retryable() bool {
+	return e.StatusCode == 429 || e.StatusCode >= 500
+}
@@ -0,0 +1,31 @@
+package client
+
+// Key 배치 조회/무효화 대상
+type Key struct {
+	Topic string `json:"topic"`
+	Key   string `json:"key"`
+}
+
+// SetItem 배치 저장 대상
+type SetItem struct {
+	Topic string `json:"topic"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	TTL   int    `json:"ttl"`
+}
+
+// GetResult 배치 조회 결과. Error 는 항목별 서버 오류
+type GetResult struct {
+	Topic string `json:"topic"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Found bool   `json:"found"`
+	Error string `json:"error,omitempty"`
+}
+
+// Result 배치 저장/무효화 결과
+type Result struct {
+	Topic string `json:"topic"`
+	Key   string `json:"key"`
+	Error string `json:"error,omitempty"`
+}
@@ -0,0 +1,84 @@
+// dlq DLQ 메시지 조회 및 재발행 CLI
+//
+//	go run ./cmd/dlq list [-limit 100]
+//	go run ./cmd/dlq replay -partition 0 -offset 42
+package main
+
+import (
+	"cache/config"
+	"cache/core/event_broker"
+	"cache/logger"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	logger.Init()
+
+	fs := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	configPath := fs.String("config", "config.yaml", "config file path")
+	limit := fs.Int("limit", 100, "max messages to list (0 = all)")
+	partition := fs.Int("partition", 0, "DLQ partition to replay")
+	offset := fs.Int64("offset", -1, "DLQ offset to replay")
+	timeout := fs.Duration("timeout", 30*time.Second, "operation timeout")
+	_ = fs.Parse(os.Args[2:])
+
+	conf, err := config.Load(*configPath, nil)
+	if err != nil {
+		fail("config load failed: %v", err)
+	}
+	if err := conf.Validate(); err != nil {
+		fail("%v", err)
+	}
+	broker, err := event_broker.NewKafkaBroker(conf.EventBroker.Kafka)
+	if err != nil {
+		fail("kafka connection failed: %v", err)
+	}
+	defer broker.Close()
+
+	dlq, ok := event_broker.AsDeadLetterQueue(broker)
+	if !ok {
+		fail("event broker does not support dead letters")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	switch os.Args[1] {
+	case "list":
+		letters, err := dlq.DeadLetters(ctx, *limit)
+		if err != nil {
+			fail("list failed: %v", err)
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(letters)
+	case "replay":
+		if *offset < 0 {
+			fail("-offset is required")
+		}
+		if err := dlq.ReplayDeadLetter(ctx, *partition, *offset); err != nil {
+			fail("replay failed: %v", err)
+		}
+		fmt.Printf("replayed %d/%d\n", *partition, *offset)
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: dlq <list|replay> [-config path] [-limit n] [-partition p -offset o]")
+	os.Exit(2)
+}
+
+func fail(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "❌ "+format+"\n", args...)
+	os.Exit(1)
+}
@@ -1,23 +1,129 @@
 package config
 
 import (
+	"context"
 	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
 )
 
-func Load(path string) (*Config, error) {
-	viper.SetConfigFile(path)
-	viper.SetConfigType("yaml")
+// Load 설정 우선순위: overrides (CLI flag) > 환경 변수 > 설정 파일 > 기본값.
+// 환경 변수 이름은 YAML 경로를 대문자로 바꾸고 "." 을 "_" 로 바꾼 것이다 (예: CACHE_REDIS_PASSWORD).
+// 목록 값은 쉼표로 구분한다 (예: EVENT_BROKER_KAFKA_BROKERS=a:9092,b:9092).
+// 호출마다 새 viper 인스턴스를 사용하므로 reload 중 다시 호출해도 안전하다.
+func Load(path string, overrides map[string]interface{}) (*Config, error) {
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	setDefaults(v)
 
-	if err := viper.ReadInConfig(); err != nil {
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+	// 설정 파일에 없는 키도 환경 변수로 지정할 수 있도록 모든 키를 등록
+	if err := bindEnvs(v, reflect.TypeOf(Config{}), ""); err != nil {
+		return nil, fmt.Errorf("failed to bind env: %w", err)
+	}
+
+	if err := v.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config: %w", err)
 	}
+	// 이전 log.level 설정도 계속 읽는다. logging.level 이 있으면 그쪽이 우선
+	if v.InConfig("log.level") {
+		v.SetDefault("logging.level", v.Get("log.level"))
+	}
+	for key, val := range overrides {
+		v.Set(key, val)
+	}
 
 	var conf Config
-	if err := viper.Unmarshal(&conf); err != nil {
+	if err := v.Unmarshal(&conf); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
 	return &conf, nil
 }
+
+// Watch ctx 가 끝날 때까지 설정 파일이 바뀌면 onChange 호출.
+// 편집기의 rename 저장이나 Kubernetes ConfigMap 의 symlink 교체도 잡도록 파일이 아닌 디렉터리를 감시한다
+func Watch(ctx context.Context, path string, onChange func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch config: %w", err)
+	}
+	file := filepath.Clean(path)
+	if err := watcher.Add(filepath.Dir(file)); err != nil {
+		_ = watcher.Close()
+		return fmt.Errorf("failed to watch config: %w", err)
+	}
+
+	go func() {
+		defer watcher.Close()
+		real, _ := filepath.EvalSymlinks(file)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				current, _ := filepath.EvalSymlinks(file)
+				written := filepath.Clean(event.Name) == file && event.Has(fsnotify.Write|fsnotify.Create)
+				if written || (current != "" && current != real) {
+					real = current
+					onChange()
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func setDefaults(v *viper.Viper) {
+	v.SetDefault("http.address", ":8000")
+	v.SetDefault("http.read_timeout_seconds", 30)
+	v.SetDefault("http.read_header_timeout_seconds", 10)
+	v.SetDefault("http.write_timeout_seconds", 30)
+	v.SetDefault("http.idle_timeout_seconds", 120)
+	v.SetDefault("http.max_header_bytes", 1<<20)
+	v.SetDefault("http.max_body_bytes", 10<<20)
+	v.SetDefault("http.tls.reload_interval_seconds", 30)
+	v.SetDefault("logging.level", "info")
+	v.SetDefault("logging.format", "json")
+	v.SetDefault("logging.sampling.initial", 100)
+	v.SetDefault("logging.sampling.thereafter", 100)
+	v.SetDefault("logging.access_log", true)
+	v.SetDefault("tenancy.header", "X-Tenant-ID")
+}
+
+func bindEnvs(v *viper.Viper, t reflect.Type, prefix string) error {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("mapstructure")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		key := tag
+		if prefix != "" {
+			key = prefix + "." + tag
+		}
+		if f.Type.Kind() == reflect.Struct {
+			if err := bindEnvs(v, f.Type, key); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := v.BindEnv(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
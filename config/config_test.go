@@ -0,0 +1,89 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeConfig(t *testing.T, yaml string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := writeConfig(t, `
+http:
+  address: ":9000"
+logging:
+  level: warn
+cache:
+  redis:
+    address: "file:6379"
+`)
+	t.Setenv("CACHE_REDIS_ADDRESS", "env:6379")
+	t.Setenv("LOGGING_LEVEL", "error")
+
+	conf, err := Load(path, map[string]interface{}{"logging.level": "debug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.HTTP.Address != ":9000" {
+		t.Errorf("http.address = %q, want the file value", conf.HTTP.Address)
+	}
+	if conf.Cache.Redis.Address != "env:6379" {
+		t.Errorf("cache.redis.address = %q, want the env value", conf.Cache.Redis.Address)
+	}
+	if conf.Logging.Level != "debug" {
+		t.Errorf("logging.level = %q, want the flag value", conf.Logging.Level)
+	}
+	if conf.HTTP.MaxBodyBytes != 10<<20 {
+		t.Errorf("http.max_body_bytes = %d, want the default", conf.HTTP.MaxBodyBytes)
+	}
+}
+
+func TestLoadEnvForKeysMissingFromFile(t *testing.T) {
+	path := writeConfig(t, "http:\n  address: \":8000\"\n")
+	t.Setenv("EVENT_BROKER_KAFKA_BROKERS", "kafka-1:9092,kafka-2:9092")
+	t.Setenv("CACHE_REDIS_PASSWORD", "secret")
+
+	conf, err := Load(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"kafka-1:9092", "kafka-2:9092"}; !slices.Equal(conf.EventBroker.Kafka.Brokers, want) {
+		t.Errorf("brokers = %v, want %v", conf.EventBroker.Kafka.Brokers, want)
+	}
+	if conf.Cache.Redis.Password != "secret" {
+		t.Errorf("password = %q", conf.Cache.Redis.Password)
+	}
+}
+
+func TestLoadReadsLegacyLogLevel(t *testing.T) {
+	conf, err := Load(writeConfig(t, "log:\n  level: warn\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.Logging.Level != "warn" {
+		t.Fatalf("logging.level = %q, want the log.level value", conf.Logging.Level)
+	}
+
+	conf, err = Load(writeConfig(t, "log:\n  level: warn\nlogging:\n  level: error\n"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if conf.Logging.Level != "error" {
+		t.Fatalf("logging.level = %q, want logging.level to win", conf.Logging.Level)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml"), nil); err == nil {
+		t.Fatal("expected error for a missing config file")
+	}
+}
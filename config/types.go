@@ -1,22 +1,207 @@
 package config
 
 type Config struct {
+	HTTP         HTTPConfig         `mapstructure:"http"`
+	Logging      LoggingConfig      `mapstructure:"logging"`
+	Auth         AuthConfig         `mapstructure:"auth"`
+	RateLimit    RateLimitConfig    `mapstructure:"rate_limit"`
+	Tenancy      TenancyConfig      `mapstructure:"tenancy"`
 	Cache        CacheConfig        `mapstructure:"cache"`
 	EventBroker  EventBrokerConfig  `mapstructure:"event_broker"`
 	Invalidation InvalidationConfig `mapstructure:"invalidation"`
+	GRPC         GRPCConfig         `mapstructure:"grpc"`
+	Stream       StreamConfig       `mapstructure:"stream"`
+	Tracing      TracingConfig      `mapstructure:"tracing"`
+	Health       HealthConfig       `mapstructure:"health"`
+	Metrics      MetricsConfig      `mapstructure:"metrics"`
+	Shutdown     ShutdownConfig     `mapstructure:"shutdown"`
+}
+
+// HTTP
+type HTTPConfig struct {
+	Address string `mapstructure:"address"` // 예: :8000
+
+	ReadTimeoutSeconds       int `mapstructure:"read_timeout_seconds"`        // 요청 전체(body 포함) 읽기 제한
+	ReadHeaderTimeoutSeconds int `mapstructure:"read_header_timeout_seconds"` // 요청 헤더 읽기 제한 (slowloris 방지)
+	WriteTimeoutSeconds      int `mapstructure:"write_timeout_seconds"`       // 응답 쓰기 제한, 스트림은 쓰기마다 갱신
+	IdleTimeoutSeconds       int `mapstructure:"idle_timeout_seconds"`        // keep-alive 유휴 연결 유지 시간
+
+	MaxHeaderBytes int   `mapstructure:"max_header_bytes"`
+	MaxBodyBytes   int64 `mapstructure:"max_body_bytes"`
+
+	TLS TLSConfig `mapstructure:"tls"`
+}
+
+// TLSConfig 인증서는 디스크에서 바뀌면 재시작 없이 다시 읽는다
+type TLSConfig struct {
+	Enabled               bool   `mapstructure:"enabled"`
+	CertFile              string `mapstructure:"cert_file"`
+	KeyFile               string `mapstructure:"key_file"`
+	ClientCAFile          string `mapstructure:"client_ca_file"`          // 지정하면 mTLS: 이 CA 가 서명한 client 인증서 필수
+	ReloadIntervalSeconds int    `mapstructure:"reload_interval_seconds"` // 인증서 파일 변경 확인 주기
+}
+
+// Auth HTTP API 인증 및 topic 별 권한
+type AuthConfig struct {
+	Enabled bool           `mapstructure:"enabled"`
+	APIKeys []APIKeyConfig `mapstructure:"api_keys"`
+	HMAC    HMACConfig     `mapstructure:"hmac"`
+	JWT     JWTConfig      `mapstructure:"jwt"`
+	ACL     []ACLRule      `mapstructure:"acl"`
+	Audit   AuditConfig    `mapstructure:"audit"`
+}
+
+// APIKeyConfig X-API-Key 헤더로 전달하는 정적 key. 값은 key 또는 key_env 환경변수에서 읽는다
+type APIKeyConfig struct {
+	ID     string `mapstructure:"id"` // principal 이름
+	Key    string `mapstructure:"key"`
+	KeyEnv string `mapstructure:"key_env"`
+}
+
+// HMACConfig 요청 서명 검증. 서명 대상은 method, path, timestamp, body hash
+type HMACConfig struct {
+	Keys           []HMACKeyConfig `mapstructure:"keys"`
+	MaxSkewSeconds int             `mapstructure:"max_skew_seconds"` // 허용 시각 오차
+}
+
+type HMACKeyConfig struct {
+	ID        string `mapstructure:"id"` // principal 이름이자 X-Auth-Key 값
+	Secret    string `mapstructure:"secret"`
+	SecretEnv string `mapstructure:"secret_env"`
+}
+
+// JWTConfig Bearer 토큰 검증. 서명 key 는 JWKS 파일 또는 URL 에서 읽는다
+type JWTConfig struct {
+	Enabled        bool   `mapstructure:"enabled"`
+	JWKSFile       string `mapstructure:"jwks_file"`
+	JWKSURL        string `mapstructure:"jwks_url"`
+	RefreshSeconds int    `mapstructure:"refresh_seconds"` // JWKS 다시 읽는 주기
+	Issuer         string `mapstructure:"issuer"`
+	Audience       string `mapstructure:"audience"`
+	SubjectClaim   string `mapstructure:"subject_claim"` // principal 로 쓸 claim, 기본 sub
+}
+
+// ACLRule principal 에게 topic 패턴(path.Match 형식) 별 권한을 부여
+type ACLRule struct {
+	Principal   string   `mapstructure:"principal"`   // "*" 는 인증된 모든 principal
+	Topics      []string `mapstructure:"topics"`      // 예: users, orders-*, *
+	Permissions []string `mapstructure:"permissions"` // read, write, invalidate, admin
+}
+
+// AuditConfig 거부된 요청 기록
+type AuditConfig struct {
+	File string `mapstructure:"file"` // JSON lines, 비어 있으면 stdout
+}
+
+// RateLimit set/invalidate 요청 token bucket 제한. requests_per_second 가 0 이면 해당 제한 없음
+type RateLimitConfig struct {
+	Enabled   bool                   `mapstructure:"enabled"`
+	PerClient LimitConfig            `mapstructure:"per_client"` // 인증된 principal, 없으면 client IP 단위
+	PerTopic  LimitConfig            `mapstructure:"per_topic"`
+	Topics    map[string]LimitConfig `mapstructure:"topics"` // topic 별 per_topic 재정의
+}
+
+type LimitConfig struct {
+	RequestsPerSecond float64 `mapstructure:"requests_per_second"`
+	Burst             int     `mapstructure:"burst"`
+}
+
+// Tenancy 한 클러스터를 여러 팀이 나눠 쓸 때 key/topic/quota 를 tenant 별로 분리
+type TenancyConfig struct {
+	Enabled bool           `mapstructure:"enabled"`
+	Header  string         `mapstructure:"header"`  // tenant 를 지정하는 헤더 (gRPC 는 같은 이름의 metadata)
+	Default string         `mapstructure:"default"` // 헤더가 없을 때 사용할 tenant, 비어 있으면 거부
+	Tenants []TenantConfig `mapstructure:"tenants"`
+}
+
+type TenantConfig struct {
+	ID                string         `mapstructure:"id"`
+	Principals        []string       `mapstructure:"principals"`          // 이 tenant 로 고정되는 인증 principal
+	KeyPrefix         string         `mapstructure:"key_prefix"`          // 기본 "<id>:"
+	KafkaTopic        string         `mapstructure:"kafka_topic"`         // invalidation 발행 topic, 비어 있으면 공용 topic
+	DefaultTTLSeconds int            `mapstructure:"default_ttl_seconds"` // 0 이면 invalidation 설정을 따른다
+	TopicTTLs         map[string]int `mapstructure:"topic_ttls"`
+	Quota             QuotaConfig    `mapstructure:"quota"`
+}
+
+// Logging
+type LoggingConfig struct {
+	Level     string            `mapstructure:"level"`      // debug, info, warn, error
+	Format    string            `mapstructure:"format"`     // json, console
+	File      string            `mapstructure:"file"`       // 비우면 stderr
+	Sampling  LogSamplingConfig `mapstructure:"sampling"`   // 같은 메시지가 몰릴 때 일부만 기록
+	AccessLog bool              `mapstructure:"access_log"` // HTTP 요청마다 access log 기록
+}
+
+// LogSamplingConfig 초당 같은 level/메시지 중 처음 Initial 개는 모두, 이후에는 Thereafter 개마다 하나만 기록
+type LogSamplingConfig struct {
+	Enabled    bool `mapstructure:"enabled"`
+	Initial    int  `mapstructure:"initial"`
+	Thereafter int  `mapstructure:"thereafter"`
 }
 
 // Cache
 type CacheConfig struct {
-	Type  string      `mapstructure:"type"` // redis, memcached
-	Redis RedisConfig `mapstructure:"redis"`
+	Type       string           `mapstructure:"type"` // redis, memory
+	Redis      RedisConfig      `mapstructure:"redis"`
+	Memory     MemoryConfig     `mapstructure:"memory"`
+	Encryption EncryptionConfig `mapstructure:"encryption"`
+	Breaker    BreakerConfig    `mapstructure:"breaker"`
+	Quota      QuotaConfig      `mapstructure:"quota"`
+}
+
+// QuotaConfig topic 별 key 수/값 크기 상한. 노드 단위로 집계하며 0 이면 제한 없음
+type QuotaConfig struct {
+	Default TopicQuota            `mapstructure:"default"`
+	Topics  map[string]TopicQuota `mapstructure:"topics"` // topic 별 재정의
+}
+
+type TopicQuota struct {
+	MaxKeys  int   `mapstructure:"max_keys"`
+	MaxBytes int64 `mapstructure:"max_bytes"` // 값 크기 합계
 }
 
 type RedisConfig struct {
-	Address    string `mapstructure:"address"`
-	Password   string `mapstructure:"password"`
-	DB         int    `mapstructure:"db"`
-	TTLSeconds int    `mapstructure:"ttl_seconds"`
+	Address    string      `mapstructure:"address"`
+	Password   string      `mapstructure:"password"`
+	DB         int         `mapstructure:"db"`
+	TTLSeconds int         `mapstructure:"ttl_seconds"`
+	Retry      RetryConfig `mapstructure:"retry"`
+}
+
+// MemoryConfig 로컬 메모리 캐시. type: memory 또는 breaker 의 fallback tier 에 사용
+type MemoryConfig struct {
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+// BreakerConfig cache backend circuit breaker
+type BreakerConfig struct {
+	Enabled            bool    `mapstructure:"enabled"`
+	WindowSize         int     `mapstructure:"window_size"`          // 실패율 계산에 쓰는 최근 호출 수
+	MinRequests        int     `mapstructure:"min_requests"`         // window 에 이만큼 쌓여야 판단
+	FailureRate        float64 `mapstructure:"failure_rate"`         // 0.0 ~ 1.0, 이상이면 open
+	OpenSeconds        int     `mapstructure:"open_seconds"`         // open 유지 후 half-open 전환
+	HalfOpenProbes     int     `mapstructure:"half_open_probes"`     // half-open 에서 close 까지 필요한 연속 성공
+	ReadFallback       string  `mapstructure:"read_fallback"`        // miss, memory
+	WriteFallback      string  `mapstructure:"write_fallback"`       // drop, queue, memory
+	QueueSize          int     `mapstructure:"queue_size"`           // queue/memory 모드에서 보관할 key 수 (key 별 마지막 쓰기만 보관)
+	FallbackTTLSeconds int     `mapstructure:"fallback_ttl_seconds"` // memory fallback 에 보관하는 최대 TTL
+}
+
+type EncryptionConfig struct {
+	Enabled     bool     `mapstructure:"enabled"`
+	Topics      []string `mapstructure:"topics"`        // 암호화 대상 topic
+	ActiveKeyID string   `mapstructure:"active_key_id"` // 새 값 암호화에 사용할 key
+	KeyFile     string   `mapstructure:"key_file"`      // key_id: base64 key 형식의 yaml/json
+	KeyEnv      string   `mapstructure:"key_env"`       // "id1=base64,id2=base64" 형식의 환경변수 이름
+}
+
+// RetryConfig backend 연결 재시도 정책
+type RetryConfig struct {
+	FailFast        bool `mapstructure:"fail_fast"`        // startup 연결 실패 시 종료 (startup 에만 적용)
+	StartupAttempts int  `mapstructure:"startup_attempts"` // startup 연결 확인 횟수
+	BackoffBaseMs   int  `mapstructure:"backoff_base_ms"`
+	BackoffMaxMs    int  `mapstructure:"backoff_max_ms"`
 }
 
 // Event Broker
@@ -30,6 +215,18 @@ type KafkaConfig struct {
 	Topics  []string          `mapstructure:"topics"`
 	GroupID string            `mapstructure:"group_id"`
 	Reader  KafkaReaderConfig `mapstructure:"reader"`
+	Retry   RetryConfig       `mapstructure:"retry"`
+
+	DeadLetter DeadLetterConfig `mapstructure:"dead_letter"`
+}
+
+// DeadLetterConfig 재시도를 모두 소진한 메시지를 retry topic 에서 지연 재처리한 뒤 DLQ 로 보낸다
+type DeadLetterConfig struct {
+	Enabled      bool   `mapstructure:"enabled"`
+	RetryTopic   string `mapstructure:"retry_topic"`
+	Topic        string `mapstructure:"topic"`
+	RetryDelayMs int    `mapstructure:"retry_delay_ms"` // milliseconds
+	MaxRounds    int    `mapstructure:"max_rounds"`     // retry topic 재처리 횟수, 0 이면 바로 DLQ
 }
 
 type KafkaReaderConfig struct {
@@ -37,15 +234,99 @@ type KafkaReaderConfig struct {
 	MaxBytes      int `mapstructure:"max_bytes"`
 	MaxWaitMs     int `mapstructure:"max_wait_ms"` // milliseconds
 	QueueCapacity int `mapstructure:"queue_capacity"`
+
+	Workers         int `mapstructure:"workers"`           // 병렬 처리 worker 수, 같은 key 는 같은 worker
+	WorkerQueueSize int `mapstructure:"worker_queue_size"` // worker 별 대기열 크기
+
+	HandlerRetries   int `mapstructure:"handler_retries"`    // 처리 실패 시 추가 시도 횟수
+	CommitBatchSize  int `mapstructure:"commit_batch_size"`  // 이 수만큼 처리되면 commit
+	CommitIntervalMs int `mapstructure:"commit_interval_ms"` // milliseconds, 배치가 차지 않아도 주기적으로 commit
+
+	Replay ReplayConfig `mapstructure:"replay"`
+}
+
+// ReplayConfig 시작 시 group 소비 전에 놓친 invalidation 을 다시 적용. 끝날 때까지 readiness 는 down
+type ReplayConfig struct {
+	Mode           string `mapstructure:"mode"`            // none, timestamp, offset, checkpoint
+	Since          string `mapstructure:"since"`           // timestamp: RFC3339 시각 또는 현재 기준 기간 (예: 15m)
+	Offset         int64  `mapstructure:"offset"`          // offset: 모든 partition 의 시작 offset, -2 는 가장 처음
+	CheckpointFile string `mapstructure:"checkpoint_file"` // commit 한 offset 을 기록하는 노드 로컬 파일
 }
 
 // Invalidation
 type InvalidationConfig struct {
 	Strategy  string            `mapstructure:"strategy"` // versioned-key, ttl-aware
 	Versioned VersionedStrategy `mapstructure:"versioned"`
+
+	DefaultTTLSeconds int            `mapstructure:"default_ttl_seconds"` // TTL 을 지정하지 않은 쓰기에 적용, 0 이면 만료 없음
+	TopicTTLs         map[string]int `mapstructure:"topic_ttls"`          // topic 별 기본 TTL (초)
 }
 
 type VersionedStrategy struct {
 	Delimiter      string `mapstructure:"delimiter"`
 	DefaultVersion int    `mapstructure:"default_version"`
 }
+
+// gRPC
+type GRPCConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Address string `mapstructure:"address"` // ex) ":9000"
+}
+
+// Invalidation Stream (SSE / WebSocket)
+type StreamConfig struct {
+	ClientBuffer     int `mapstructure:"client_buffer"` // 클라이언트별 대기 이벤트 수, 초과 시 연결 종료
+	HeartbeatSeconds int `mapstructure:"heartbeat_seconds"`
+	// AllowedOrigins WebSocket 을 열 수 있는 다른 출처 (예: https://app.example.com). 같은 host 는 항상 허용, "*" 는 모두 허용
+	AllowedOrigins []string `mapstructure:"allowed_origins"`
+}
+
+// Tracing (OpenTelemetry)
+type TracingConfig struct {
+	Enabled     bool    `mapstructure:"enabled"`
+	Endpoint    string  `mapstructure:"endpoint"` // OTLP gRPC collector, ex) "localhost:4317"
+	Insecure    bool    `mapstructure:"insecure"`
+	ServiceName string  `mapstructure:"service_name"`
+	SampleRatio float64 `mapstructure:"sample_ratio"` // 0.0 ~ 1.0
+}
+
+// Metrics
+type MetricsConfig struct {
+	Topics []string `mapstructure:"topics"` // 다른 설정에 나오지 않지만 metric label 로 구분할 topic
+}
+
+// MetricTopics metric 의 topic label 로 쓸 topic. 설정에 나오는 topic 만 모아 label 수를 제한한다
+func (c *Config) MetricTopics() []string {
+	topics := append([]string(nil), c.Metrics.Topics...)
+	topics = append(topics, c.Cache.Encryption.Topics...)
+	for t := range c.Invalidation.TopicTTLs {
+		topics = append(topics, t)
+	}
+	for t := range c.Cache.Quota.Topics {
+		topics = append(topics, t)
+	}
+	for t := range c.RateLimit.Topics {
+		topics = append(topics, t)
+	}
+	for _, tc := range c.Tenancy.Tenants {
+		for t := range tc.TopicTTLs {
+			topics = append(topics, t)
+		}
+		for t := range tc.Quota.Topics {
+			topics = append(topics, t)
+		}
+	}
+	return topics
+}
+
+// Health
+type HealthConfig struct {
+	IntervalSeconds  int `mapstructure:"interval_seconds"`
+	TimeoutMs        int `mapstructure:"timeout_ms"`
+	FailureThreshold int `mapstructure:"failure_threshold"` // 연속 실패 횟수 기준 readiness 전환
+}
+
+// Shutdown
+type ShutdownConfig struct {
+	TimeoutSeconds int `mapstructure:"timeout_seconds"` // 모든 종료 단계가 공유하는 deadline
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMetricTopicsCollectsConfiguredTopics(t *testing.T) {
+	c := &Config{
+		Metrics:      MetricsConfig{Topics: []string{"extra"}},
+		Invalidation: InvalidationConfig{TopicTTLs: map[string]int{"users": 60}},
+		RateLimit:    RateLimitConfig{Topics: map[string]LimitConfig{"orders": {}}},
+		Tenancy: TenancyConfig{Tenants: []TenantConfig{{
+			ID:        "team-a",
+			TopicTTLs: map[string]int{"sessions": 30},
+			Quota:     QuotaConfig{Topics: map[string]TopicQuota{"carts": {}}},
+		}}},
+	}
+	c.Cache.Encryption.Topics = []string{"secrets"}
+	c.Cache.Quota.Topics = map[string]TopicQuota{"images": {}}
+
+	got := c.MetricTopics()
+	for _, want := range []string{"extra", "users", "orders", "sessions", "carts", "secrets", "images"} {
+		if !slices.Contains(got, want) {
+			t.Errorf("MetricTopics() = %v, missing %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,398 @@
+package config
+
+import (
+	"fmt"
+	pathpkg "path"
+	"strings"
+	"time"
+)
+
+// FieldError 설정 검증 실패 항목. Path 는 YAML 경로
+type FieldError struct {
+	Path    string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationError 발견된 모든 검증 실패 항목
+type ValidationError []FieldError
+
+func (e ValidationError) Error() string {
+	lines := make([]string, 0, len(e)+1)
+	lines = append(lines, fmt.Sprintf("invalid config (%d problems):", len(e)))
+	for _, fe := range e {
+		lines = append(lines, "  - "+fe.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Validate 필수 값, 허용 값, 범위, 항목 간 규칙을 검사해 모든 문제를 한 번에 보고한다
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	c.HTTP.validate(v)
+	c.Logging.validate(v)
+
+	c.Auth.validate(v)
+	c.RateLimit.validate(v)
+	c.Tenancy.validate(v, c.EventBroker.Kafka.Topics)
+	c.Cache.validate(v)
+	c.EventBroker.validate(v)
+	c.Invalidation.validate(v)
+
+	if c.GRPC.Enabled {
+		v.required("grpc.address", c.GRPC.Address)
+		if c.GRPC.Address != "" && c.GRPC.Address == c.HTTP.Address {
+			v.add("grpc.address", "must differ from http.address (%s)", c.HTTP.Address)
+		}
+	}
+
+	v.min("stream.client_buffer", c.Stream.ClientBuffer, 0)
+	v.min("stream.heartbeat_seconds", c.Stream.HeartbeatSeconds, 0)
+
+	if c.Tracing.Enabled {
+		v.required("tracing.endpoint", c.Tracing.Endpoint)
+		v.ratio("tracing.sample_ratio", c.Tracing.SampleRatio)
+	}
+
+	v.min("health.interval_seconds", c.Health.IntervalSeconds, 0)
+	v.min("health.timeout_ms", c.Health.TimeoutMs, 0)
+	v.min("health.failure_threshold", c.Health.FailureThreshold, 0)
+	v.min("shutdown.timeout_seconds", c.Shutdown.TimeoutSeconds, 0)
+
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+	return nil
+}
+
+func (c LoggingConfig) validate(v *validator) {
+	v.enum("logging.level", c.Level, "debug", "info", "warn", "error")
+	v.enum("logging.format", c.Format, "json", "console")
+	if c.Sampling.Enabled {
+		v.min("logging.sampling.initial", c.Sampling.Initial, 1)
+		v.min("logging.sampling.thereafter", c.Sampling.Thereafter, 1)
+	}
+}
+
+func (c HTTPConfig) validate(v *validator) {
+	v.required("http.address", c.Address)
+	v.min("http.read_timeout_seconds", c.ReadTimeoutSeconds, 0)
+	v.min("http.read_header_timeout_seconds", c.ReadHeaderTimeoutSeconds, 0)
+	v.min("http.write_timeout_seconds", c.WriteTimeoutSeconds, 0)
+	v.min("http.idle_timeout_seconds", c.IdleTimeoutSeconds, 0)
+	v.min("http.max_header_bytes", c.MaxHeaderBytes, 0)
+	if c.MaxBodyBytes < 0 {
+		v.add("http.max_body_bytes", "must be >= 0, got %d", c.MaxBodyBytes)
+	}
+	if c.TLS.Enabled {
+		v.required("http.tls.cert_file", c.TLS.CertFile)
+		v.required("http.tls.key_file", c.TLS.KeyFile)
+		v.min("http.tls.reload_interval_seconds", c.TLS.ReloadIntervalSeconds, 0)
+	} else if c.TLS.ClientCAFile != "" {
+		v.add("http.tls.client_ca_file", "requires http.tls.enabled")
+	}
+}
+
+func (c AuthConfig) validate(v *validator) {
+	if !c.Enabled {
+		return
+	}
+	if len(c.APIKeys) == 0 && len(c.HMAC.Keys) == 0 && !c.JWT.Enabled {
+		v.add("auth", "at least one of api_keys, hmac.keys or jwt must be configured when auth is enabled")
+	}
+	for i, k := range c.APIKeys {
+		path := fmt.Sprintf("auth.api_keys[%d]", i)
+		v.required(path+".id", k.ID)
+		if k.Key == "" && k.KeyEnv == "" {
+			v.add(path+".key", "key or key_env is required")
+		}
+	}
+	for i, k := range c.HMAC.Keys {
+		path := fmt.Sprintf("auth.hmac.keys[%d]", i)
+		v.required(path+".id", k.ID)
+		if k.Secret == "" && k.SecretEnv == "" {
+			v.add(path+".secret", "secret or secret_env is required")
+		}
+	}
+	v.min("auth.hmac.max_skew_seconds", c.HMAC.MaxSkewSeconds, 0)
+	if c.JWT.Enabled {
+		if (c.JWT.JWKSFile == "") == (c.JWT.JWKSURL == "") {
+			v.add("auth.jwt.jwks_file", "exactly one of jwks_file or jwks_url is required")
+		}
+		v.min("auth.jwt.refresh_seconds", c.JWT.RefreshSeconds, 0)
+	}
+	for i, rule := range c.ACL {
+		path := fmt.Sprintf("auth.acl[%d]", i)
+		v.required(path+".principal", rule.Principal)
+		if len(rule.Topics) == 0 {
+			v.add(path+".topics", "at least one topic pattern is required")
+		}
+		for j, t := range rule.Topics {
+			if _, err := pathpkg.Match(t, ""); err != nil {
+				v.add(fmt.Sprintf("%s.topics[%d]", path, j), "invalid pattern %q: %v", t, err)
+			}
+		}
+		if len(rule.Permissions) == 0 {
+			v.add(path+".permissions", "at least one permission is required")
+		}
+		for j, p := range rule.Permissions {
+			v.enum(fmt.Sprintf("%s.permissions[%d]", path, j), p, "read", "write", "invalidate", "admin")
+		}
+	}
+}
+
+func (c RateLimitConfig) validate(v *validator) {
+	if !c.Enabled {
+		return
+	}
+	c.PerClient.validate(v, "rate_limit.per_client")
+	c.PerTopic.validate(v, "rate_limit.per_topic")
+	for topic, l := range c.Topics {
+		l.validate(v, "rate_limit.topics."+topic)
+	}
+}
+
+func (c TenancyConfig) validate(v *validator, kafkaTopics []string) {
+	if !c.Enabled {
+		return
+	}
+	if len(c.Tenants) == 0 {
+		v.add("tenancy.tenants", "at least one tenant is required when tenancy is enabled")
+	}
+	ids := make(map[string]bool, len(c.Tenants))
+	principals := make(map[string]string)
+	prefixes := make(map[string]string)
+	for i, t := range c.Tenants {
+		path := fmt.Sprintf("tenancy.tenants[%d]", i)
+		v.required(path+".id", t.ID)
+		if ids[t.ID] {
+			v.add(path+".id", "duplicate tenant %q", t.ID)
+		}
+		ids[t.ID] = true
+		for _, p := range t.Principals {
+			if other, ok := principals[p]; ok {
+				v.add(path+".principals", "principal %q is already assigned to tenant %q", p, other)
+			}
+			principals[p] = t.ID
+		}
+		if t.KeyPrefix != "" {
+			if other, ok := prefixes[t.KeyPrefix]; ok {
+				v.add(path+".key_prefix", "prefix %q is already used by tenant %q", t.KeyPrefix, other)
+			}
+			prefixes[t.KeyPrefix] = t.ID
+		}
+		if t.KafkaTopic != "" && !contains(kafkaTopics, t.KafkaTopic) {
+			v.add(path+".kafka_topic", "topic %q must be listed in event_broker.kafka.topics", t.KafkaTopic)
+		}
+		v.min(path+".default_ttl_seconds", t.DefaultTTLSeconds, 0)
+		for topic, ttl := range t.TopicTTLs {
+			v.min(path+".topic_ttls."+topic, ttl, 0)
+		}
+		t.Quota.Default.validate(v, path+".quota.default")
+		for topic, q := range t.Quota.Topics {
+			q.validate(v, path+".quota.topics."+topic)
+		}
+	}
+	if c.Default != "" && !ids[c.Default] {
+		v.add("tenancy.default", "unknown tenant %q", c.Default)
+	}
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (c LimitConfig) validate(v *validator, path string) {
+	if c.RequestsPerSecond < 0 {
+		v.add(path+".requests_per_second", "must be >= 0, got %g", c.RequestsPerSecond)
+	}
+	v.min(path+".burst", c.Burst, 0)
+}
+
+func (q TopicQuota) validate(v *validator, path string) {
+	v.min(path+".max_keys", q.MaxKeys, 0)
+	if q.MaxBytes < 0 {
+		v.add(path+".max_bytes", "must be >= 0, got %d", q.MaxBytes)
+	}
+}
+
+func (c CacheConfig) validate(v *validator) {
+	v.enum("cache.type", c.Type, "redis", "memory")
+	if c.Type == "redis" {
+		v.required("cache.redis.address", c.Redis.Address)
+		v.min("cache.redis.db", c.Redis.DB, 0)
+		v.min("cache.redis.ttl_seconds", c.Redis.TTLSeconds, 0)
+		c.Redis.Retry.validate(v, "cache.redis.retry")
+	}
+	v.min("cache.memory.max_entries", c.Memory.MaxEntries, 0)
+
+	if b := c.Breaker; b.Enabled {
+		v.min("cache.breaker.window_size", b.WindowSize, 0)
+		v.min("cache.breaker.min_requests", b.MinRequests, 0)
+		if b.WindowSize > 0 && b.MinRequests > b.WindowSize {
+			v.add("cache.breaker.min_requests", "must not exceed window_size (%d)", b.WindowSize)
+		}
+		v.ratio("cache.breaker.failure_rate", b.FailureRate)
+		v.min("cache.breaker.open_seconds", b.OpenSeconds, 0)
+		v.min("cache.breaker.half_open_probes", b.HalfOpenProbes, 0)
+		v.optionalEnum("cache.breaker.read_fallback", b.ReadFallback, "miss", "memory")
+		v.optionalEnum("cache.breaker.write_fallback", b.WriteFallback, "drop", "queue", "memory")
+		v.min("cache.breaker.queue_size", b.QueueSize, 0)
+		v.min("cache.breaker.fallback_ttl_seconds", b.FallbackTTLSeconds, 0)
+	}
+
+	c.Quota.Default.validate(v, "cache.quota.default")
+	for topic, q := range c.Quota.Topics {
+		q.validate(v, "cache.quota.topics."+topic)
+	}
+
+	if e := c.Encryption; e.Enabled {
+		v.required("cache.encryption.active_key_id", e.ActiveKeyID)
+		if e.KeyFile == "" && e.KeyEnv == "" {
+			v.add("cache.encryption.key_file", "key_file or key_env is required when encryption is enabled")
+		}
+	}
+}
+
+func (r RetryConfig) validate(v *validator, path string) {
+	v.min(path+".startup_attempts", r.StartupAttempts, 0)
+	v.min(path+".backoff_base_ms", r.BackoffBaseMs, 0)
+	v.min(path+".backoff_max_ms", r.BackoffMaxMs, 0)
+	if r.BackoffMaxMs > 0 && r.BackoffMaxMs < r.BackoffBaseMs {
+		v.add(path+".backoff_max_ms", "must be >= backoff_base_ms (%d)", r.BackoffBaseMs)
+	}
+}
+
+func (c EventBrokerConfig) validate(v *validator) {
+	v.enum("event_broker.type", c.Type, "kafka")
+	if c.Type != "kafka" {
+		return
+	}
+
+	k := c.Kafka
+	if len(k.Brokers) == 0 {
+		v.add("event_broker.kafka.brokers", "at least one broker is required")
+	}
+	for i, b := range k.Brokers {
+		v.required(fmt.Sprintf("event_broker.kafka.brokers[%d]", i), b)
+	}
+	if len(k.Topics) == 0 {
+		v.add("event_broker.kafka.topics", "at least one topic is required")
+	}
+	v.required("event_broker.kafka.group_id", k.GroupID)
+	k.Retry.validate(v, "event_broker.kafka.retry")
+
+	r := k.Reader
+	v.min("event_broker.kafka.reader.min_bytes", r.MinBytes, 0)
+	v.min("event_broker.kafka.reader.max_bytes", r.MaxBytes, 0)
+	if r.MaxBytes > 0 && r.MaxBytes < r.MinBytes {
+		v.add("event_broker.kafka.reader.max_bytes", "must be >= min_bytes (%d)", r.MinBytes)
+	}
+	v.min("event_broker.kafka.reader.max_wait_ms", r.MaxWaitMs, 0)
+	v.min("event_broker.kafka.reader.queue_capacity", r.QueueCapacity, 0)
+	v.min("event_broker.kafka.reader.workers", r.Workers, 0)
+	v.min("event_broker.kafka.reader.worker_queue_size", r.WorkerQueueSize, 0)
+	v.min("event_broker.kafka.reader.handler_retries", r.HandlerRetries, 0)
+	v.min("event_broker.kafka.reader.commit_batch_size", r.CommitBatchSize, 0)
+	v.min("event_broker.kafka.reader.commit_interval_ms", r.CommitIntervalMs, 0)
+
+	rp := r.Replay
+	v.optionalEnum("event_broker.kafka.reader.replay.mode", rp.Mode, "none", "timestamp", "offset", "checkpoint")
+	switch rp.Mode {
+	case "timestamp":
+		if _, err := time.Parse(time.RFC3339, rp.Since); err != nil {
+			if _, err := time.ParseDuration(rp.Since); err != nil {
+				v.add("event_broker.kafka.reader.replay.since", "must be an RFC3339 time or a duration like 15m, got %q", rp.Since)
+			}
+		}
+	case "offset":
+		if rp.Offset < -2 {
+			v.add("event_broker.kafka.reader.replay.offset", "must be >= -2, got %d", rp.Offset)
+		}
+	case "checkpoint":
+		v.required("event_broker.kafka.reader.replay.checkpoint_file", rp.CheckpointFile)
+	}
+
+	if d := k.DeadLetter; d.Enabled {
+		v.min("event_broker.kafka.dead_letter.retry_delay_ms", d.RetryDelayMs, 0)
+		v.min("event_broker.kafka.dead_letter.max_rounds", d.MaxRounds, 0)
+		if d.RetryTopic != "" && d.RetryTopic == d.Topic {
+			v.add("event_broker.kafka.dead_letter.topic", "must differ from retry_topic (%s)", d.RetryTopic)
+		}
+		for _, t := range k.Topics {
+			if t == d.RetryTopic {
+				v.add("event_broker.kafka.dead_letter.retry_topic", "must not be one of kafka.topics (%s)", t)
+			}
+			if t == d.Topic {
+				v.add("event_broker.kafka.dead_letter.topic", "must not be one of kafka.topics (%s)", t)
+			}
+		}
+	}
+}
+
+func (c InvalidationConfig) validate(v *validator) {
+	v.enum("invalidation.strategy", c.Strategy, "versioned-key")
+	if c.Strategy == "versioned-key" {
+		v.required("invalidation.versioned.delimiter", c.Versioned.Delimiter)
+		v.min("invalidation.versioned.default_version", c.Versioned.DefaultVersion, 0)
+	}
+	v.min("invalidation.default_ttl_seconds", c.DefaultTTLSeconds, 0)
+	for topic, ttl := range c.TopicTTLs {
+		v.min("invalidation.topic_ttls."+topic, ttl, 0)
+	}
+}
+
+type validator struct {
+	errs ValidationError
+}
+
+func (v *validator) add(path string, format string, args ...interface{}) {
+	v.errs = append(v.errs, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) required(path string, val string) {
+	if strings.TrimSpace(val) == "" {
+		v.add(path, "is required")
+	}
+}
+
+func (v *validator) enum(path string, val string, allowed ...string) {
+	for _, a := range allowed {
+		if val == a {
+			return
+		}
+	}
+	if val == "" {
+		v.add(path, "is required (one of %s)", strings.Join(allowed, ", "))
+		return
+	}
+	v.add(path, "must be one of %s, got %q", strings.Join(allowed, ", "), val)
+}
+
+// optionalEnum 비어 있으면 기본값을 쓰는 항목
+func (v *validator) optionalEnum(path string, val string, allowed ...string) {
+	if val != "" {
+		v.enum(path, val, allowed...)
+	}
+}
+
+func (v *validator) min(path string, val int, min int) {
+	if val < min {
+		v.add(path, "must be >= %d, got %d", min, val)
+	}
+}
+
+func (v *validator) ratio(path string, val float64) {
+	if val < 0 || val > 1 {
+		v.add(path, "must be between 0.0 and 1.0, got %g", val)
+	}
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// loadRepoConfig 저장소의 config.yaml. 배포 기본 설정이 항상 유효한지 확인하는 기준으로 쓴다
+func loadRepoConfig(t *testing.T) *Config {
+	t.Helper()
+	conf, err := Load("../config.yaml", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conf
+}
+
+func TestRepoConfigIsValid(t *testing.T) {
+	if err := loadRepoConfig(t).Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateReportsEveryProblemWithItsPath(t *testing.T) {
+	conf := loadRepoConfig(t)
+	conf.Logging.Level = "verbose"
+	conf.HTTP.Address = ""
+	conf.HTTP.TLS.ClientCAFile = "ca.pem"
+	conf.GRPC.Enabled = true
+	conf.GRPC.Address = conf.HTTP.Address
+
+	err := conf.Validate()
+	var verr ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("err = %v, want ValidationError", err)
+	}
+	paths := make(map[string]bool)
+	for _, fe := range verr {
+		paths[fe.Path] = true
+	}
+	for _, want := range []string{"logging.level", "http.address", "http.tls.client_ca_file", "grpc.address"} {
+		if !paths[want] {
+			t.Errorf("missing problem for %s in:\n%v", want, err)
+		}
+	}
+	if !strings.HasPrefix(err.Error(), "invalid config (") {
+		t.Errorf("message = %q", err.Error())
+	}
+}
+
+func TestValidateAuth(t *testing.T) {
+	conf := loadRepoConfig(t)
+	conf.Auth = AuthConfig{
+		Enabled: true,
+		APIKeys: []APIKeyConfig{{ID: "svc"}},
+		ACL:     []ACLRule{{Principal: "svc", Topics: []string{"[users"}, Permissions: []string{"delete"}}},
+	}
+
+	err := conf.Validate()
+	for _, want := range []string{"auth.api_keys[0].key", "auth.acl[0].topics[0]", "auth.acl[0].permissions[0]"} {
+		if err == nil || !strings.Contains(err.Error(), want+":") {
+			t.Errorf("missing problem for %s in:\n%v", want, err)
+		}
+	}
+}
+
+func TestValidateTenancy(t *testing.T) {
+	conf := loadRepoConfig(t)
+	conf.Tenancy = TenancyConfig{
+		Enabled: true,
+		Default: "nobody",
+		Tenants: []TenantConfig{
+			{ID: "team-a", Principals: []string{"svc"}, KafkaTopic: "not-listed"},
+			{ID: "team-a", Principals: []string{"svc"}},
+		},
+	}
+
+	err := conf.Validate()
+	for _, want := range []string{"tenancy.tenants[0].kafka_topic", "tenancy.tenants[1].id", "tenancy.tenants[1].principals", "tenancy.default"} {
+		if err == nil || !strings.Contains(err.Error(), want+":") {
+			t.Errorf("missing problem for %s in:\n%v", want, err)
+		}
+	}
+}
@@ -12,19 +12,41 @@ import (
 
 // NewCacheAdapter Cache 어댑터 생성
 func NewCacheAdapter(cfg config.CacheConfig) (_interface.ICacheAdapter, error) {
+	var adapter _interface.ICacheAdapter
+	var err error
 	switch cfg.Type {
 	case "redis":
-		return cache_adapter.NewRedisAdapter(cfg.Redis), nil
+		if adapter, err = cache_adapter.NewRedisAdapter(cfg.Redis); err != nil {
+			return nil, err
+		}
+	case "memory":
+		adapter = cache_adapter.NewMemoryAdapter(cfg.Memory)
 	default:
 		return nil, fmt.Errorf("unsupported cache type: %s", cfg.Type)
 	}
+
+	if cfg.Encryption.Enabled {
+		if adapter, err = cache_adapter.NewEncryptedAdapter(adapter, cfg.Encryption); err != nil {
+			return nil, err
+		}
+	}
+	adapter = cache_adapter.NewTracingAdapter(cache_adapter.NewMetricsAdapter(adapter), cfg.Type)
+	// breaker 는 가장 바깥에서 backend 호출 전체(암호화 포함)의 실패를 관측한다
+	if cfg.Breaker.Enabled {
+		adapter = cache_adapter.NewBreakerAdapter(adapter, cfg.Breaker, cfg.Memory)
+	}
+	return adapter, nil
 }
 
 // NewEventBroker Event Broker 생성
 func NewEventBroker(cfg config.EventBrokerConfig) (_interface.IEventBroker, error) {
 	switch cfg.Type {
 	case "kafka":
-		return event_broker.NewKafkaBroker(cfg.Kafka), nil
+		broker, err := event_broker.NewKafkaBroker(cfg.Kafka)
+		if err != nil {
+			return nil, err
+		}
+		return event_broker.NewTracingBroker(event_broker.NewMetricsBroker(broker), cfg.Type), nil
 	default:
 		return nil, fmt.Errorf("unsupported event broker type: %s", cfg.Type)
 	}
@@ -34,7 +56,7 @@ func NewEventBroker(cfg config.EventBrokerConfig) (_interface.IEventBroker, erro
 func NewInvalidationStrategy(cfg config.InvalidationConfig) (_interface.IInvalidationStrategy, error) {
 	switch cfg.Strategy {
 	case "versioned-key":
-		return strategy.NewVersionedKeyStrategy(cfg.Versioned), nil
+		return strategy.NewVersionedKeyStrategy(cfg), nil
 	default:
 		return nil, errors.New("unsupported invalidation strategy: " + cfg.Strategy)
 	}
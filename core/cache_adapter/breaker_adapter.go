@@ -0,0 +1,450 @@
+package cache_adapter
+
+import (
+	"cache/config"
+	_interface "cache/interface"
+	"cache/logger"
+	"cache/metrics"
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateHalfOpen
+	stateOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case stateHalfOpen:
+		return "half-open"
+	case stateOpen:
+		return "open"
+	default:
+		return "closed"
+	}
+}
+
+const (
+	fallbackMiss   = "miss"
+	fallbackMemory = "memory"
+	fallbackDrop   = "drop"
+	fallbackQueue  = "queue"
+)
+
+// pendingWrite backend 장애 중 보관했다가 복구 후 재실행할 쓰기
+type pendingWrite struct {
+	invalidate bool
+	key        string
+	value      string
+	ttl        int
+	queuedAt   time.Time
+	seq        uint64 // 재실행 중 같은 key 에 새 쓰기가 들어왔는지 구분
+}
+
+// replayTTL 보관된 시간만큼 줄인 TTL. 보관 중 만료됐으면 false
+func (w pendingWrite) replayTTL(now time.Time) (int, bool) {
+	if w.ttl <= 0 {
+		return w.ttl, true
+	}
+	left := w.ttl - int(now.Sub(w.queuedAt)/time.Second)
+	return left, left > 0
+}
+
+type breakerAdapter struct {
+	next   _interface.ICacheAdapter
+	memory _interface.ICacheAdapter // fallback tier, 사용하지 않으면 nil
+	cfg    config.BreakerConfig
+	log    *zap.SugaredLogger
+
+	mu             sync.Mutex
+	state          breakerState
+	window         []bool // true = 실패
+	pos            int
+	count          int
+	failures       int
+	openedAt       time.Time
+	probesInFlight int
+	probeSuccesses int
+	queue          map[string]pendingWrite // key 별 마지막 쓰기만 보관
+	order          []string                // queue 에 들어온 key 순서
+	seq            uint64
+	flushing       bool
+}
+
+// NewBreakerAdapter 실패율 기반 circuit breaker. open 상태에서는 backend 를 호출하지 않고 degraded mode 로 응답
+func NewBreakerAdapter(next _interface.ICacheAdapter, cfg config.BreakerConfig, memCfg config.MemoryConfig) _interface.ICacheAdapter {
+	if cfg.WindowSize <= 0 {
+		cfg.WindowSize = 20
+	}
+	if cfg.MinRequests <= 0 || cfg.MinRequests > cfg.WindowSize {
+		cfg.MinRequests = cfg.WindowSize / 2
+	}
+	if cfg.FailureRate <= 0 {
+		cfg.FailureRate = 0.5
+	}
+	if cfg.OpenSeconds <= 0 {
+		cfg.OpenSeconds = 10
+	}
+	if cfg.HalfOpenProbes <= 0 {
+		cfg.HalfOpenProbes = 1
+	}
+	if cfg.ReadFallback == "" {
+		cfg.ReadFallback = fallbackMiss
+	}
+	if cfg.WriteFallback == "" {
+		cfg.WriteFallback = fallbackDrop
+	}
+	if cfg.FallbackTTLSeconds <= 0 {
+		cfg.FallbackTTLSeconds = 60
+	}
+
+	b := &breakerAdapter{
+		next:   next,
+		cfg:    cfg,
+		log:    logger.Logger,
+		window: make([]bool, cfg.WindowSize),
+		queue:  make(map[string]pendingWrite),
+	}
+	if cfg.ReadFallback == fallbackMemory || cfg.WriteFallback == fallbackMemory {
+		b.memory = NewMemoryAdapter(memCfg)
+	}
+	metrics.BreakerState.Set(float64(stateClosed))
+	return b
+}
+
+// BreakerState health 보고용 현재 상태
+func (b *breakerAdapter) BreakerState() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state.String()
+}
+
+func (b *breakerAdapter) Get(ctx context.Context, key string) (string, error) {
+	probe, ok := b.allow()
+	if !ok {
+		return b.degradedGet(ctx, key)
+	}
+
+	val, err := b.next.Get(ctx, key)
+	if errors.Is(err, _interface.ErrNotFound) {
+		// 미스는 정상 응답이므로 실패로 세지 않는다. fallback 에 남은 예전 값도 버린다
+		b.record(nil, probe)
+		if b.memory != nil {
+			_ = b.memory.Invalidate(ctx, key)
+		}
+		return "", err
+	}
+	b.record(err, probe)
+	if err != nil {
+		if !isBackendFailure(err) {
+			return "", err
+		}
+		return b.degradedGet(ctx, key)
+	}
+	if b.memory != nil {
+		// 조회만으로는 backend TTL 을 알 수 없으므로 fallback TTL 동안만 보관
+		_ = b.memory.Set(ctx, key, val, b.cfg.FallbackTTLSeconds)
+	}
+	return val, nil
+}
+
+func (b *breakerAdapter) Set(ctx context.Context, key string, value string, ttlSeconds int) error {
+	probe, ok := b.allow()
+	if !ok {
+		return b.degradedWrite(ctx, pendingWrite{key: key, value: value, ttl: ttlSeconds})
+	}
+
+	err := b.next.Set(ctx, key, value, ttlSeconds)
+	if err == nil {
+		b.dequeue(key)
+	}
+	b.record(err, probe)
+	if err != nil {
+		if !isBackendFailure(err) {
+			return err
+		}
+		return b.degradedWrite(ctx, pendingWrite{key: key, value: value, ttl: ttlSeconds})
+	}
+	if b.memory != nil {
+		_ = b.memory.Set(ctx, key, value, b.fallbackTTL(ttlSeconds))
+	}
+	return nil
+}
+
+func (b *breakerAdapter) Invalidate(ctx context.Context, key string) error {
+	if b.memory != nil {
+		_ = b.memory.Invalidate(ctx, key)
+	}
+
+	probe, ok := b.allow()
+	if !ok {
+		return b.degradedWrite(ctx, pendingWrite{invalidate: true, key: key})
+	}
+
+	err := b.next.Invalidate(ctx, key)
+	if err == nil {
+		b.dequeue(key)
+	}
+	b.record(err, probe)
+	if err != nil {
+		if !isBackendFailure(err) {
+			return err
+		}
+		return b.degradedWrite(ctx, pendingWrite{invalidate: true, key: key})
+	}
+	return nil
+}
+
+func (b *breakerAdapter) Ping(ctx context.Context) error {
+	return b.next.Ping(ctx)
+}
+
+func (b *breakerAdapter) Close() error {
+	return b.next.Close()
+}
+
+func (b *breakerAdapter) degradedGet(ctx context.Context, key string) (string, error) {
+	if b.cfg.ReadFallback == fallbackMemory && b.memory != nil {
+		metrics.DegradedOps.WithLabelValues("get", fallbackMemory).Inc()
+		return b.memory.Get(ctx, key)
+	}
+	metrics.DegradedOps.WithLabelValues("get", fallbackMiss).Inc()
+	return "", _interface.ErrNotFound
+}
+
+func (b *breakerAdapter) degradedWrite(ctx context.Context, w pendingWrite) error {
+	op := "set"
+	if w.invalidate {
+		op = "invalidate"
+	}
+
+	switch b.cfg.WriteFallback {
+	case fallbackQueue, fallbackMemory:
+		if b.cfg.WriteFallback == fallbackMemory && !w.invalidate {
+			_ = b.memory.Set(ctx, w.key, w.value, b.fallbackTTL(w.ttl))
+		}
+		b.enqueue(w)
+		metrics.DegradedOps.WithLabelValues(op, b.cfg.WriteFallback).Inc()
+	default:
+		b.log.Warnf("🗑️ Cache %s dropped while backend unavailable [key=%s]", op, w.key)
+		metrics.DegradedOps.WithLabelValues(op, fallbackDrop).Inc()
+	}
+	if w.invalidate {
+		// 무효화가 반영되지 않았음을 알려 broker 가 재시도/DLQ 처리하게 한다
+		return _interface.ErrUnavailable
+	}
+	return nil
+}
+
+// fallbackTTL memory fallback 에 보관할 TTL. 만료 없는 값도 fallback TTL 이 지나면 사라진다
+func (b *breakerAdapter) fallbackTTL(ttlSeconds int) int {
+	if ttlSeconds <= 0 || ttlSeconds > b.cfg.FallbackTTLSeconds {
+		return b.cfg.FallbackTTLSeconds
+	}
+	return ttlSeconds
+}
+
+// enqueue key 마다 마지막 쓰기만 남긴다. 나중의 invalidate 는 앞선 set 을 대체한다
+func (b *breakerAdapter) enqueue(w pendingWrite) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	w.queuedAt, w.seq = time.Now(), b.seq
+	if _, ok := b.queue[w.key]; !ok {
+		if b.cfg.QueueSize > 0 && len(b.queue) >= b.cfg.QueueSize {
+			b.log.Warnf("🗑️ Degraded write queue full, dropping [key=%s]", w.key)
+			metrics.DegradedOps.WithLabelValues("queue", "overflow").Inc()
+			return
+		}
+		b.order = append(b.order, w.key)
+	}
+	b.queue[w.key] = w
+	metrics.DegradedQueueDepth.Set(float64(len(b.queue)))
+}
+
+// dequeue backend 에 바로 반영된 key 의 보관 쓰기는 더 오래된 값이므로 버린다
+func (b *breakerAdapter) dequeue(key string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.removeQueued(key)
+}
+
+// removeQueued b.mu 를 잡은 상태에서 호출
+func (b *breakerAdapter) removeQueued(key string) {
+	if _, ok := b.queue[key]; !ok {
+		return
+	}
+	delete(b.queue, key)
+	for i, k := range b.order {
+		if k == key {
+			b.order = append(b.order[:i], b.order[i+1:]...)
+			break
+		}
+	}
+	metrics.DegradedQueueDepth.Set(float64(len(b.queue)))
+}
+
+// allow backend 호출 가능 여부. half-open 에서는 제한된 수의 probe 만 허용
+func (b *breakerAdapter) allow() (probe bool, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case stateOpen:
+		if time.Since(b.openedAt) < time.Duration(b.cfg.OpenSeconds)*time.Second {
+			return false, false
+		}
+		b.transition(stateHalfOpen)
+		fallthrough
+	case stateHalfOpen:
+		// 보관한 쓰기를 재실행하는 동안에는 새 요청이 backend 에 먼저 닿지 않도록 degraded 로 처리
+		if b.flushing || b.probesInFlight >= b.cfg.HalfOpenProbes {
+			return false, false
+		}
+		b.probesInFlight++
+		return true, true
+	default:
+		return false, true
+	}
+}
+
+// isBackendFailure backend 장애로 볼 에러. 요청 자체의 문제(취소, 잘못된 값 등)는 실패로 세지 않는다
+func isBackendFailure(err error) bool {
+	if errors.Is(err, _interface.ErrUnavailable) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func (b *breakerAdapter) record(err error, probe bool) {
+	failed := isBackendFailure(err)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probesInFlight--
+		if b.state != stateHalfOpen {
+			return
+		}
+		if failed {
+			b.transition(stateOpen)
+			return
+		}
+		b.probeSuccesses++
+		if b.probeSuccesses < b.cfg.HalfOpenProbes || b.probesInFlight > 0 || b.flushing {
+			return
+		}
+		if len(b.queue) == 0 {
+			b.transition(stateClosed)
+			return
+		}
+		// queue 를 비운 뒤에 closed 로 전환한다
+		b.flushing = true
+		go b.flush()
+		return
+	}
+
+	if b.state != stateClosed {
+		return
+	}
+	if b.count == len(b.window) {
+		if b.window[b.pos] {
+			b.failures--
+		}
+	} else {
+		b.count++
+	}
+	b.window[b.pos] = failed
+	if failed {
+		b.failures++
+	}
+	b.pos = (b.pos + 1) % len(b.window)
+
+	if b.count >= b.cfg.MinRequests && float64(b.failures)/float64(b.count) >= b.cfg.FailureRate {
+		b.transition(stateOpen)
+	}
+}
+
+// transition b.mu 를 잡은 상태에서 호출
+func (b *breakerAdapter) transition(to breakerState) {
+	if b.state == to {
+		return
+	}
+	b.log.Warnf("⚡ Cache circuit breaker %s -> %s", b.state, to)
+	b.state = to
+	metrics.BreakerState.Set(float64(to))
+	metrics.BreakerTransitions.WithLabelValues(to.String()).Inc()
+
+	switch to {
+	case stateOpen:
+		b.openedAt = time.Now()
+	case stateHalfOpen:
+		b.probeSuccesses = 0
+	case stateClosed:
+		b.count, b.failures, b.pos = 0, 0, 0
+	}
+}
+
+// flush half-open 상태에서 보관한 쓰기를 순서대로 재실행하고, 모두 반영되면 closed 로 전환.
+// backend 장애로 실패하면 남은 쓰기를 유지한 채 다시 open 한다
+func (b *breakerAdapter) flush() {
+	b.mu.Lock()
+	b.log.Infof("🔁 Replaying %d degraded writes", len(b.queue))
+	b.mu.Unlock()
+
+	ctx := context.Background()
+	for {
+		b.mu.Lock()
+		if len(b.order) == 0 {
+			b.flushing = false
+			b.transition(stateClosed)
+			metrics.DegradedQueueDepth.Set(0)
+			b.mu.Unlock()
+			return
+		}
+		key := b.order[0]
+		w := b.queue[key]
+		b.mu.Unlock()
+
+		var err error
+		if ttl, ok := w.replayTTL(time.Now()); !w.invalidate && ok {
+			err = b.next.Set(ctx, w.key, w.value, ttl)
+		} else {
+			// 보관 중 만료된 set 은 backend 의 예전 값도 남지 않도록 무효화로 재실행
+			err = b.next.Invalidate(ctx, w.key)
+		}
+
+		b.mu.Lock()
+		if err != nil && isBackendFailure(err) {
+			b.log.Warnf("❗ Degraded write replay failed, reopening [key=%s]: %v", w.key, err)
+			b.flushing = false
+			b.transition(stateOpen)
+			b.mu.Unlock()
+			return
+		}
+		if err != nil {
+			// 다시 시도해도 같은 결과인 쓰기는 버리고 나머지를 계속 재실행
+			b.log.Errorf("❌ Dropping degraded write [key=%s]: %v", w.key, err)
+			metrics.DegradedOps.WithLabelValues("replay", fallbackDrop).Inc()
+		}
+		if cur, ok := b.queue[key]; ok && cur.seq != w.seq {
+			// 재실행하는 동안 더 새로운 쓰기가 들어왔으면 그것을 이어서 재실행
+			b.mu.Unlock()
+			continue
+		}
+		b.removeQueued(key)
+		b.mu.Unlock()
+	}
+}
@@ -0,0 +1,372 @@
+package cache_adapter
+
+import (
+	"cache/config"
+	_interface "cache/interface"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// stubBackend 호출마다 err 를 반환하는 backend. err 가 nil 이면 values 로 동작.
+// keyErrs 에 있는 key 의 쓰기는 해당 에러로 실패한다
+type stubBackend struct {
+	err     error
+	keyErrs map[string]error
+	values  map[string]string
+	ttls    map[string]int
+}
+
+func newStubBackend() *stubBackend {
+	return &stubBackend{keyErrs: make(map[string]error), values: make(map[string]string), ttls: make(map[string]int)}
+}
+
+func (s *stubBackend) Get(_ context.Context, key string) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	v, ok := s.values[key]
+	if !ok {
+		return "", _interface.ErrNotFound
+	}
+	return v, nil
+}
+
+func (s *stubBackend) Set(_ context.Context, key string, value string, ttl int) error {
+	if s.err != nil {
+		return s.err
+	}
+	if err := s.keyErrs[key]; err != nil {
+		return err
+	}
+	s.values[key] = value
+	s.ttls[key] = ttl
+	return nil
+}
+
+func (s *stubBackend) Invalidate(_ context.Context, key string) error {
+	if s.err != nil {
+		return s.err
+	}
+	if err := s.keyErrs[key]; err != nil {
+		return err
+	}
+	delete(s.values, key)
+	return nil
+}
+
+func (s *stubBackend) Ping(context.Context) error { return s.err }
+func (s *stubBackend) Close() error               { return nil }
+
+func newTestBreaker(next _interface.ICacheAdapter, read string, write string) *breakerAdapter {
+	return NewBreakerAdapter(next, config.BreakerConfig{
+		Enabled:       true,
+		WindowSize:    4,
+		MinRequests:   4,
+		FailureRate:   0.5,
+		OpenSeconds:   60,
+		ReadFallback:  read,
+		WriteFallback: write,
+	}, config.MemoryConfig{}).(*breakerAdapter)
+}
+
+func TestBreakerCountsOnlyBackendFailures(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantOpen bool
+	}{
+		{"unavailable", fmt.Errorf("redis: %w", _interface.ErrUnavailable), true},
+		{"timeout", context.DeadlineExceeded, true},
+		{"canceled", context.Canceled, false},
+		{"bad request", errors.New("malformed encrypted value"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := newStubBackend()
+			backend.err = tt.err
+			b := newTestBreaker(backend, fallbackMiss, fallbackDrop)
+
+			for i := 0; i < 4; i++ {
+				_, _ = b.Get(context.Background(), "users:1")
+			}
+			if open := b.BreakerState() == "open"; open != tt.wantOpen {
+				t.Fatalf("open = %v, want %v", open, tt.wantOpen)
+			}
+		})
+	}
+}
+
+func TestBreakerReturnsNonBackendErrors(t *testing.T) {
+	backend := newStubBackend()
+	backend.err = errors.New("malformed encrypted value")
+	b := newTestBreaker(backend, fallbackMemory, fallbackQueue)
+
+	if _, err := b.Get(context.Background(), "users:1"); !errors.Is(err, backend.err) {
+		t.Fatalf("Get err = %v, want %v", err, backend.err)
+	}
+	if err := b.Set(context.Background(), "users:1", "v", 0); !errors.Is(err, backend.err) {
+		t.Fatalf("Set err = %v, want %v", err, backend.err)
+	}
+}
+
+func TestOpenBreakerFailsInvalidate(t *testing.T) {
+	for _, write := range []string{fallbackDrop, fallbackQueue, fallbackMemory} {
+		t.Run(write, func(t *testing.T) {
+			backend := newStubBackend()
+			backend.err = _interface.ErrUnavailable
+			b := newTestBreaker(backend, fallbackMiss, write)
+			for i := 0; i < 4; i++ {
+				_, _ = b.Get(context.Background(), "users:1")
+			}
+
+			if err := b.Invalidate(context.Background(), "users:1"); !errors.Is(err, _interface.ErrUnavailable) {
+				t.Fatalf("Invalidate err = %v, want ErrUnavailable", err)
+			}
+			if err := b.Set(context.Background(), "users:1", "v", 0); err != nil {
+				t.Fatalf("Set err = %v, want degraded success", err)
+			}
+		})
+	}
+}
+
+func TestMemoryFallbackTTLIsBounded(t *testing.T) {
+	backend := newStubBackend()
+	backend.values["users:1"] = "v1"
+	b := newTestBreaker(backend, fallbackMemory, fallbackDrop)
+	ctx := context.Background()
+
+	if _, err := b.Get(ctx, "users:1"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := b.Set(ctx, "users:2", "v2", 3600); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	memory := b.memory.(*memoryAdapter)
+	limit := time.Now().Add(time.Duration(b.cfg.FallbackTTLSeconds) * time.Second)
+	for _, key := range []string{"users:1", "users:2"} {
+		el, ok := memory.items[key]
+		if !ok {
+			t.Fatalf("%s not in memory fallback", key)
+		}
+		expiresAt := el.Value.(*memoryEntry).expiresAt
+		if expiresAt.IsZero() || expiresAt.After(limit) {
+			t.Fatalf("%s expiresAt = %v, want <= %v", key, expiresAt, limit)
+		}
+	}
+}
+
+func TestInvalidateDropsMemoryFallback(t *testing.T) {
+	backend := newStubBackend()
+	backend.values["users:1"] = "v1"
+	b := newTestBreaker(backend, fallbackMemory, fallbackDrop)
+	ctx := context.Background()
+
+	if _, err := b.Get(ctx, "users:1"); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	backend.err = _interface.ErrUnavailable
+	for i := 0; i < 4; i++ {
+		_, _ = b.Get(ctx, "users:2")
+	}
+	if got, err := b.Get(ctx, "users:1"); err != nil || got != "v1" {
+		t.Fatalf("fallback Get = %q, %v", got, err)
+	}
+
+	_ = b.Invalidate(ctx, "users:1")
+	if _, err := b.Get(ctx, "users:1"); !errors.Is(err, _interface.ErrNotFound) {
+		t.Fatalf("Get after Invalidate err = %v, want ErrNotFound", err)
+	}
+}
+
+// openBreaker backend 장애로 breaker 를 연다
+func openBreaker(t *testing.T, b *breakerAdapter, backend *stubBackend) {
+	t.Helper()
+	backend.err = _interface.ErrUnavailable
+	for i := 0; i < 4; i++ {
+		_, _ = b.Get(context.Background(), "probe")
+	}
+	if state := b.BreakerState(); state != "open" {
+		t.Fatalf("state = %s, want open", state)
+	}
+}
+
+// recoverBreaker open 시간이 지난 것으로 만들고 backend 를 복구한 뒤 probe 를 보내 재실행이 끝나기를 기다린다
+func recoverBreaker(t *testing.T, b *breakerAdapter, backend *stubBackend) {
+	t.Helper()
+	b.mu.Lock()
+	b.openedAt = time.Now().Add(-time.Hour)
+	b.mu.Unlock()
+	backend.err = nil
+
+	if _, err := b.Get(context.Background(), "probe"); !errors.Is(err, _interface.ErrNotFound) {
+		t.Fatalf("probe Get err = %v, want backend miss", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for {
+		b.mu.Lock()
+		done := !b.flushing
+		b.mu.Unlock()
+		if done {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("queued writes were not replayed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestHalfOpenProbeClosesAndReplaysQueue(t *testing.T) {
+	backend := newStubBackend()
+	b := newTestBreaker(backend, fallbackMiss, fallbackQueue)
+	ctx := context.Background()
+	openBreaker(t, b, backend)
+	if err := b.Set(ctx, "users:1", "queued", 0); err != nil {
+		t.Fatalf("Set err = %v, want degraded success", err)
+	}
+
+	recoverBreaker(t, b, backend)
+	if state := b.BreakerState(); state != "closed" {
+		t.Fatalf("state = %s, want closed after replay", state)
+	}
+	if v := backend.values["users:1"]; v != "queued" {
+		t.Fatalf("replayed value = %q", v)
+	}
+}
+
+func TestQueueKeepsLastWritePerKey(t *testing.T) {
+	backend := newStubBackend()
+	backend.values["users:2"] = "stale"
+	b := newTestBreaker(backend, fallbackMiss, fallbackQueue)
+	ctx := context.Background()
+	openBreaker(t, b, backend)
+
+	_ = b.Set(ctx, "users:1", "v1", 0)
+	_ = b.Set(ctx, "users:1", "v2", 0)
+	_ = b.Set(ctx, "users:2", "v1", 0)
+	_ = b.Invalidate(ctx, "users:2")
+	b.mu.Lock()
+	depth := len(b.order)
+	b.mu.Unlock()
+	if depth != 2 {
+		t.Fatalf("queue depth = %d, want one entry per key", depth)
+	}
+
+	recoverBreaker(t, b, backend)
+	if v := backend.values["users:1"]; v != "v2" {
+		t.Fatalf("users:1 = %q, want last write", v)
+	}
+	if _, ok := backend.values["users:2"]; ok {
+		t.Fatal("invalidate did not replace the earlier queued set")
+	}
+}
+
+func TestReplayShortensTTL(t *testing.T) {
+	backend := newStubBackend()
+	backend.values["users:2"] = "stale"
+	b := newTestBreaker(backend, fallbackMiss, fallbackQueue)
+	ctx := context.Background()
+	openBreaker(t, b, backend)
+
+	_ = b.Set(ctx, "users:1", "v", 60)
+	_ = b.Set(ctx, "users:2", "v", 5)
+	b.mu.Lock()
+	for k, w := range b.queue {
+		w.queuedAt = w.queuedAt.Add(-10 * time.Second)
+		b.queue[k] = w
+	}
+	b.mu.Unlock()
+
+	recoverBreaker(t, b, backend)
+	if ttl := backend.ttls["users:1"]; ttl < 49 || ttl > 50 {
+		t.Fatalf("replayed TTL = %d, want about 50", ttl)
+	}
+	// 보관 중 만료된 set 은 무효화로 재실행
+	if _, ok := backend.values["users:2"]; ok {
+		t.Fatal("expired queued set left the backend value in place")
+	}
+}
+
+func TestReplayDropsPermanentFailures(t *testing.T) {
+	backend := newStubBackend()
+	b := newTestBreaker(backend, fallbackMiss, fallbackQueue)
+	ctx := context.Background()
+	openBreaker(t, b, backend)
+
+	_ = b.Set(ctx, "users:1", "bad", 0)
+	_ = b.Set(ctx, "users:2", "good", 0)
+	backend.keyErrs["users:1"] = errors.New("value too large")
+
+	recoverBreaker(t, b, backend)
+	if state := b.BreakerState(); state != "closed" {
+		t.Fatalf("state = %s, want closed", state)
+	}
+	if v := backend.values["users:2"]; v != "good" {
+		t.Fatalf("users:2 = %q, want replayed after the failed write", v)
+	}
+}
+
+func TestReplayBackendFailureReopens(t *testing.T) {
+	backend := newStubBackend()
+	b := newTestBreaker(backend, fallbackMiss, fallbackQueue)
+	ctx := context.Background()
+	openBreaker(t, b, backend)
+
+	_ = b.Set(ctx, "users:1", "v1", 0)
+	_ = b.Set(ctx, "users:2", "v2", 0)
+	backend.keyErrs["users:1"] = _interface.ErrUnavailable
+
+	recoverBreaker(t, b, backend)
+	if state := b.BreakerState(); state != "open" {
+		t.Fatalf("state = %s, want open after a failed replay", state)
+	}
+	b.mu.Lock()
+	depth := len(b.order)
+	b.mu.Unlock()
+	if depth != 2 {
+		t.Fatalf("queue depth = %d, want both writes kept", depth)
+	}
+}
+
+func TestLiveWriteSupersedesQueuedWrite(t *testing.T) {
+	backend := newStubBackend()
+	b := newTestBreaker(backend, fallbackMiss, fallbackQueue)
+	ctx := context.Background()
+
+	// closed 상태의 일시 장애로 보관된 쓰기
+	backend.keyErrs["users:1"] = _interface.ErrUnavailable
+	_ = b.Set(ctx, "users:1", "old", 0)
+	delete(backend.keyErrs, "users:1")
+
+	if err := b.Set(ctx, "users:1", "new", 0); err != nil {
+		t.Fatal(err)
+	}
+	b.mu.Lock()
+	_, queued := b.queue["users:1"]
+	b.mu.Unlock()
+	if queued {
+		t.Fatal("older queued write kept after a newer live write")
+	}
+}
+
+func TestFailedProbeReopens(t *testing.T) {
+	backend := newStubBackend()
+	backend.err = _interface.ErrUnavailable
+	b := newTestBreaker(backend, fallbackMiss, fallbackDrop)
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		_, _ = b.Get(ctx, "users:1")
+	}
+	b.mu.Lock()
+	b.openedAt = time.Now().Add(-time.Hour)
+	b.mu.Unlock()
+
+	_, _ = b.Get(ctx, "users:1")
+	if state := b.BreakerState(); state != "open" {
+		t.Fatalf("state = %s, want open after a failed probe", state)
+	}
+}
@@ -0,0 +1,197 @@
+package cache_adapter
+
+import (
+	"cache/config"
+	_interface "cache/interface"
+	"cache/logger"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// 암호화된 값 형식: enc:<keyID>:<base64(nonce|ciphertext)>
+const encryptedPrefix = "enc:"
+
+type encryptedAdapter struct {
+	next     _interface.ICacheAdapter
+	keys     map[string]cipher.AEAD
+	activeID string
+	topics   map[string]struct{}
+	log      *zap.SugaredLogger
+}
+
+// NewEncryptedAdapter 지정된 topic 의 값을 AES-GCM 으로 암호화하는 데코레이터
+func NewEncryptedAdapter(next _interface.ICacheAdapter, cfg config.EncryptionConfig) (_interface.ICacheAdapter, error) {
+	raw, err := loadEncryptionKeys(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := raw[cfg.ActiveKeyID]; !ok {
+		return nil, fmt.Errorf("active encryption key not found: %s", cfg.ActiveKeyID)
+	}
+
+	keys := make(map[string]cipher.AEAD, len(raw))
+	for id, key := range raw {
+		if strings.Contains(id, ":") {
+			return nil, fmt.Errorf("invalid encryption key id: %s", id)
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key [%s]: %w", id, err)
+		}
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key [%s]: %w", id, err)
+		}
+		keys[id] = gcm
+	}
+
+	topics := make(map[string]struct{}, len(cfg.Topics))
+	for _, t := range cfg.Topics {
+		topics[t] = struct{}{}
+	}
+
+	log := logger.Logger
+	log.Infof("🔐 Encryption enabled [topics=%v, active_key=%s, keys=%d]", cfg.Topics, cfg.ActiveKeyID, len(keys))
+
+	return &encryptedAdapter{
+		next:     next,
+		keys:     keys,
+		activeID: cfg.ActiveKeyID,
+		topics:   topics,
+		log:      log,
+	}, nil
+}
+
+// loadEncryptionKeys key 파일과 환경변수에서 key 를 읽는다. 같은 id 는 환경변수가 우선
+func loadEncryptionKeys(cfg config.EncryptionConfig) (map[string][]byte, error) {
+	encoded := make(map[string]string)
+
+	if cfg.KeyFile != "" {
+		data, err := os.ReadFile(cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read encryption key file: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &encoded); err != nil {
+			return nil, fmt.Errorf("failed to parse encryption key file: %w", err)
+		}
+	}
+
+	if cfg.KeyEnv != "" {
+		for _, pair := range strings.Split(os.Getenv(cfg.KeyEnv), ",") {
+			pair = strings.TrimSpace(pair)
+			if pair == "" {
+				continue
+			}
+			id, val, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid encryption key entry in %s", cfg.KeyEnv)
+			}
+			encoded[strings.TrimSpace(id)] = strings.TrimSpace(val)
+		}
+	}
+
+	if len(encoded) == 0 {
+		return nil, errors.New("no encryption keys configured")
+	}
+
+	keys := make(map[string][]byte, len(encoded))
+	for id, val := range encoded {
+		key, err := base64.StdEncoding.DecodeString(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 encryption key [%s]: %w", id, err)
+		}
+		keys[id] = key
+	}
+	return keys, nil
+}
+
+func (e *encryptedAdapter) shouldEncrypt(ctx context.Context, key string) bool {
+	_, ok := e.topics[topicOf(ctx, key)]
+	return ok
+}
+
+func (e *encryptedAdapter) Get(ctx context.Context, key string) (string, error) {
+	val, err := e.next.Get(ctx, key)
+	if err != nil || !e.shouldEncrypt(ctx, key) {
+		return val, err
+	}
+	if !strings.HasPrefix(val, encryptedPrefix) {
+		// 암호화 활성화 이전에 저장된 값
+		return val, nil
+	}
+	plain, err := e.decrypt(key, val)
+	if err != nil {
+		logger.With(ctx, e.log).Errorf("❗ Decrypt error [key=%s]: %v", key, err)
+		return "", err
+	}
+	return plain, nil
+}
+
+func (e *encryptedAdapter) Set(ctx context.Context, key string, value string, ttlSeconds int) error {
+	if !e.shouldEncrypt(ctx, key) {
+		return e.next.Set(ctx, key, value, ttlSeconds)
+	}
+	enc, err := e.encrypt(key, value)
+	if err != nil {
+		logger.With(ctx, e.log).Errorf("❗ Encrypt error [key=%s]: %v", key, err)
+		return err
+	}
+	return e.next.Set(ctx, key, enc, ttlSeconds)
+}
+
+func (e *encryptedAdapter) Invalidate(ctx context.Context, key string) error {
+	return e.next.Invalidate(ctx, key)
+}
+
+func (e *encryptedAdapter) Ping(ctx context.Context) error {
+	return e.next.Ping(ctx)
+}
+
+func (e *encryptedAdapter) Close() error {
+	return e.next.Close()
+}
+
+func (e *encryptedAdapter) encrypt(key string, value string) (string, error) {
+	gcm := e.keys[e.activeID]
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	// cache key 를 AAD 로 사용해 다른 key 로 값이 옮겨지는 것을 막는다
+	sealed := gcm.Seal(nonce, nonce, []byte(value), []byte(key))
+	return encryptedPrefix + e.activeID + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (e *encryptedAdapter) decrypt(key string, value string) (string, error) {
+	keyID, payload, ok := strings.Cut(strings.TrimPrefix(value, encryptedPrefix), ":")
+	if !ok {
+		return "", errors.New("malformed encrypted value")
+	}
+	gcm, ok := e.keys[keyID]
+	if !ok {
+		return "", fmt.Errorf("unknown encryption key: %s", keyID)
+	}
+	sealed, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return "", fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", errors.New("malformed encrypted value")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plain, err := gcm.Open(nil, nonce, ciphertext, []byte(key))
+	if err != nil {
+		return "", err
+	}
+	return string(plain), nil
+}
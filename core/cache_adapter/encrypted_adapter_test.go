@@ -0,0 +1,145 @@
+package cache_adapter
+
+import (
+	"cache/config"
+	_interface "cache/interface"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string(b), 32)))
+}
+
+func newTestEncrypted(t *testing.T, next _interface.ICacheAdapter, active string, keys string) _interface.ICacheAdapter {
+	t.Helper()
+	t.Setenv("TEST_ENC_KEYS", keys)
+	a, err := NewEncryptedAdapter(next, config.EncryptionConfig{
+		Enabled:     true,
+		Topics:      []string{"pii"},
+		ActiveKeyID: active,
+		KeyEnv:      "TEST_ENC_KEYS",
+	})
+	if err != nil {
+		t.Fatalf("NewEncryptedAdapter: %v", err)
+	}
+	return a
+}
+
+func TestEncryptsConfiguredTopics(t *testing.T) {
+	backend := newStubBackend()
+	a := newTestEncrypted(t, backend, "k1", "k1="+testKey('a'))
+	ctx := context.Background()
+
+	if err := a.Set(ctx, "pii:1", "secret", 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Set(ctx, "public:1", "plain", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if raw := backend.values["pii:1"]; !strings.HasPrefix(raw, "enc:k1:") || strings.Contains(raw, "secret") {
+		t.Fatalf("stored value = %q, want ciphertext", raw)
+	}
+	if raw := backend.values["public:1"]; raw != "plain" {
+		t.Fatalf("stored value = %q, want plaintext", raw)
+	}
+	if v, err := a.Get(ctx, "pii:1"); err != nil || v != "secret" {
+		t.Fatalf("Get = %q, %v", v, err)
+	}
+}
+
+func TestDecryptsWithRotatedKeys(t *testing.T) {
+	backend := newStubBackend()
+	ctx := context.Background()
+	old := newTestEncrypted(t, backend, "k1", "k1="+testKey('a'))
+	if err := old.Set(ctx, "pii:1", "before", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// k2 로 교체한 뒤에도 k1 로 암호화된 값은 읽을 수 있어야 한다
+	rotated := newTestEncrypted(t, backend, "k2", "k1="+testKey('a')+",k2="+testKey('b'))
+	if v, err := rotated.Get(ctx, "pii:1"); err != nil || v != "before" {
+		t.Fatalf("Get old value = %q, %v", v, err)
+	}
+	if err := rotated.Set(ctx, "pii:2", "after", 0); err != nil {
+		t.Fatal(err)
+	}
+	if raw := backend.values["pii:2"]; !strings.HasPrefix(raw, "enc:k2:") {
+		t.Fatalf("new value = %q, want active key k2", raw)
+	}
+
+	// k1 을 제거하면 이전 값은 복호화할 수 없다
+	retired := newTestEncrypted(t, backend, "k2", "k2="+testKey('b'))
+	if _, err := retired.Get(ctx, "pii:1"); err == nil {
+		t.Fatal("expected error for retired key")
+	}
+}
+
+func TestCiphertextIsBoundToKey(t *testing.T) {
+	backend := newStubBackend()
+	a := newTestEncrypted(t, backend, "k1", "k1="+testKey('a'))
+	ctx := context.Background()
+	if err := a.Set(ctx, "pii:1", "secret", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	backend.values["pii:2"] = backend.values["pii:1"]
+	if _, err := a.Get(ctx, "pii:2"); err == nil {
+		t.Fatal("expected error for value moved to another key")
+	}
+}
+
+func TestReadsPlaintextWrittenBeforeEncryption(t *testing.T) {
+	backend := newStubBackend()
+	backend.values["pii:1"] = "legacy"
+	a := newTestEncrypted(t, backend, "k1", "k1="+testKey('a'))
+
+	if v, err := a.Get(context.Background(), "pii:1"); err != nil || v != "legacy" {
+		t.Fatalf("Get = %q, %v", v, err)
+	}
+}
+
+func TestLoadEncryptionKeys(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "keys.yaml")
+	if err := os.WriteFile(file, []byte("k1: "+testKey('a')+"\nk2: "+testKey('b')+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEST_ENC_KEYS", "k2="+testKey('c'))
+
+	keys, err := loadEncryptionKeys(config.EncryptionConfig{KeyFile: file, KeyEnv: "TEST_ENC_KEYS"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("keys = %d, want 2", len(keys))
+	}
+	if keys["k2"][0] != 'c' {
+		t.Fatal("environment key should override the key file")
+	}
+}
+
+func TestNewEncryptedAdapterRejectsBadConfig(t *testing.T) {
+	cases := map[string]struct {
+		active string
+		keys   string
+	}{
+		"missing active key": {"k9", "k1=" + testKey('a')},
+		"short key":          {"k1", "k1=" + base64.StdEncoding.EncodeToString([]byte("short"))},
+		"bad base64":         {"k1", "k1=%%%"},
+		"no keys":            {"k1", ""},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("TEST_ENC_KEYS", tc.keys)
+			_, err := NewEncryptedAdapter(newStubBackend(), config.EncryptionConfig{ActiveKeyID: tc.active, KeyEnv: "TEST_ENC_KEYS"})
+			if err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
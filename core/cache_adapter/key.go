@@ -0,0 +1,26 @@
+package cache_adapter
+
+import (
+	"cache/tenant"
+	"context"
+	"strings"
+)
+
+type topicCtxKey struct{}
+
+// WithTopic key 를 만든 캐시 topic 을 ctx 에 담는다. tenant prefix 등으로 key 형식이 바뀌어도 adapter 가 topic 을 알 수 있다
+func WithTopic(ctx context.Context, topic string) context.Context {
+	return context.WithValue(ctx, topicCtxKey{}, topic)
+}
+
+// topicOf ctx 에 담긴 topic. 없으면 tenant prefix 를 뗀 "topic:..." 형식의 key 에서 추출
+func topicOf(ctx context.Context, key string) string {
+	if topic, ok := ctx.Value(topicCtxKey{}).(string); ok && topic != "" {
+		return topic
+	}
+	if t, ok := tenant.From(ctx); ok {
+		key = strings.TrimPrefix(key, t.KeyPrefix)
+	}
+	topic, _, _ := strings.Cut(key, ":")
+	return topic
+}
@@ -0,0 +1,35 @@
+package cache_adapter
+
+import (
+	"cache/config"
+	"cache/tenant"
+	"context"
+	"testing"
+)
+
+func TestTopicOf(t *testing.T) {
+	resolver := tenant.NewResolver(config.TenancyConfig{
+		Enabled: true,
+		Tenants: []config.TenantConfig{{ID: "acme"}},
+	})
+	acme, _ := resolver.Lookup("acme")
+	tenantCtx := tenant.WithContext(context.Background(), acme)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		key  string
+		want string
+	}{
+		{"plain key", context.Background(), "users:1:v1", "users"},
+		{"tenant prefix stripped", tenantCtx, "acme:users:1:v1", "users"},
+		{"explicit topic wins", WithTopic(tenantCtx, "pii"), "acme:users:1:v1", "pii"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := topicOf(tt.ctx, tt.key); got != tt.want {
+				t.Fatalf("topicOf(%q) = %q, want %q", tt.key, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package cache_adapter
+
+import (
+	"cache/config"
+	_interface "cache/interface"
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	key       string
+	value     string
+	expiresAt time.Time // zero 면 만료 없음
+}
+
+// memoryAdapter 프로세스 로컬 LRU 캐시. 단독 backend 또는 degraded mode 의 fallback tier 로 사용
+type memoryAdapter struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List
+	items      map[string]*list.Element
+}
+
+func NewMemoryAdapter(cfg config.MemoryConfig) _interface.ICacheAdapter {
+	maxEntries := cfg.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = 10000
+	}
+	return &memoryAdapter{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+func (m *memoryAdapter) Get(_ context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[key]
+	if !ok {
+		return "", _interface.ErrNotFound
+	}
+	entry := el.Value.(*memoryEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		m.remove(el)
+		return "", _interface.ErrNotFound
+	}
+	m.order.MoveToFront(el)
+	return entry.value, nil
+}
+
+func (m *memoryAdapter) Set(_ context.Context, key string, value string, ttlSeconds int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttlSeconds > 0 {
+		expiresAt = time.Now().Add(time.Duration(ttlSeconds) * time.Second)
+	}
+
+	if el, ok := m.items[key]; ok {
+		entry := el.Value.(*memoryEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		m.order.MoveToFront(el)
+		return nil
+	}
+
+	m.items[key] = m.order.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+	for m.order.Len() > m.maxEntries {
+		m.remove(m.order.Back())
+	}
+	return nil
+}
+
+func (m *memoryAdapter) Invalidate(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		m.remove(el)
+	}
+	return nil
+}
+
+func (m *memoryAdapter) Ping(context.Context) error {
+	return nil
+}
+
+func (m *memoryAdapter) Close() error {
+	return nil
+}
+
+func (m *memoryAdapter) remove(el *list.Element) {
+	m.order.Remove(el)
+	delete(m.items, el.Value.(*memoryEntry).key)
+}
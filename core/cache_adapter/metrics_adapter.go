@@ -0,0 +1,65 @@
+package cache_adapter
+
+import (
+	_interface "cache/interface"
+	"cache/metrics"
+	"context"
+	"errors"
+	"time"
+)
+
+type metricsAdapter struct {
+	next _interface.ICacheAdapter
+}
+
+// NewMetricsAdapter topic 별 hit/miss/error 와 latency 를 기록하는 데코레이터
+func NewMetricsAdapter(next _interface.ICacheAdapter) _interface.ICacheAdapter {
+	return &metricsAdapter{next: next}
+}
+
+func (m *metricsAdapter) Get(ctx context.Context, key string) (string, error) {
+	start := time.Now()
+	val, err := m.next.Get(ctx, key)
+	metrics.CacheLatency.WithLabelValues("get").Observe(time.Since(start).Seconds())
+
+	result := "hit"
+	switch {
+	case errors.Is(err, _interface.ErrNotFound):
+		result = "miss"
+	case err != nil:
+		result = "error"
+	}
+	metrics.CacheRequests.WithLabelValues("get", metrics.Topic(topicOf(ctx, key)), result).Inc()
+	return val, err
+}
+
+func (m *metricsAdapter) Set(ctx context.Context, key string, value string, ttlSeconds int) error {
+	start := time.Now()
+	err := m.next.Set(ctx, key, value, ttlSeconds)
+	metrics.CacheLatency.WithLabelValues("set").Observe(time.Since(start).Seconds())
+	metrics.CacheRequests.WithLabelValues("set", metrics.Topic(topicOf(ctx, key)), resultOf(err)).Inc()
+	return err
+}
+
+func (m *metricsAdapter) Invalidate(ctx context.Context, key string) error {
+	start := time.Now()
+	err := m.next.Invalidate(ctx, key)
+	metrics.CacheLatency.WithLabelValues("invalidate").Observe(time.Since(start).Seconds())
+	metrics.CacheRequests.WithLabelValues("invalidate", metrics.Topic(topicOf(ctx, key)), resultOf(err)).Inc()
+	return err
+}
+
+func (m *metricsAdapter) Ping(ctx context.Context) error {
+	return m.next.Ping(ctx)
+}
+
+func (m *metricsAdapter) Close() error {
+	return m.next.Close()
+}
+
+func resultOf(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
@@ -0,0 +1,46 @@
+package cache_adapter
+
+import (
+	"cache/metrics"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricsAdapterBoundsTopicLabel(t *testing.T) {
+	metrics.SetTopics([]string{"users"})
+	defer metrics.SetTopics(nil)
+
+	adapter := NewMetricsAdapter(newStubBackend())
+	ctx := context.Background()
+	before := testutil.ToFloat64(metrics.CacheRequests.WithLabelValues("set", metrics.OtherTopic, "ok"))
+	beforeUsers := testutil.ToFloat64(metrics.CacheRequests.WithLabelValues("set", "users", "ok"))
+
+	for _, topic := range []string{"users", "client-made-1", "client-made-2"} {
+		if err := adapter.Set(WithTopic(ctx, topic), topic+":k", "v", 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := testutil.ToFloat64(metrics.CacheRequests.WithLabelValues("set", metrics.OtherTopic, "ok")) - before; got != 2 {
+		t.Fatalf("other = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(metrics.CacheRequests.WithLabelValues("set", "users", "ok")) - beforeUsers; got != 1 {
+		t.Fatalf("users = %v, want 1", got)
+	}
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range families {
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "topic" && strings.HasPrefix(l.GetValue(), "client-made") {
+					t.Fatalf("%s has a series for %s", f.GetName(), l.GetValue())
+				}
+			}
+		}
+	}
+}
@@ -2,11 +2,12 @@ package cache_adapter
 
 import (
 	"cache/config"
+	"cache/infrautil"
 	_interface "cache/interface"
 	"cache/logger"
 	"context"
 	"errors"
-	"os"
+	"fmt"
 	"sync"
 	"time"
 
@@ -19,75 +20,119 @@ type redisAdapter struct {
 	ttl     int
 	log     *zap.SugaredLogger
 	logOnce sync.Once
+	stop    context.CancelFunc
 }
 
-func NewRedisAdapter(cfg config.RedisConfig) _interface.ICacheAdapter {
+// NewRedisAdapter startup 연결 확인이 실패하면 fail_fast 일 때만 에러를 반환하고,
+// 아니면 background 에서 backoff 로 재연결을 시도하며 adapter 를 반환한다.
+func NewRedisAdapter(cfg config.RedisConfig) (_interface.ICacheAdapter, error) {
 	log := logger.Logger
+	backoff := infrautil.NewBackoff(cfg.Retry)
 
 	rdb := redis.NewClient(&redis.Options{
-		Addr:     cfg.Address,
-		Password: cfg.Password,
-		DB:       cfg.DB,
+		Addr:            cfg.Address,
+		Password:        cfg.Password,
+		DB:              cfg.DB,
+		MinRetryBackoff: backoff.Base,
+		MaxRetryBackoff: backoff.Max,
 	})
 
 	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	adapter := &redisAdapter{
+		client: rdb,
+		ttl:    cfg.TTLSeconds,
+		log:    log,
+		stop:   cancel,
+	}
+
+	err := infrautil.Retry(ctx, backoff, cfg.Retry.StartupAttempts, func(attempt int) error {
+		log.Infof("🔄 Attempting to connect to Redis %d/%d...", attempt, max(cfg.Retry.StartupAttempts, 1))
+		return rdb.Ping(ctx).Err()
+	})
+	infrautil.LogConnectionResult(log, "Redis", err)
+	if err == nil {
+		return adapter, nil
+	}
 
-	failCount := 0
-	maxFails := 3
+	if cfg.Retry.FailFast {
+		cancel()
+		_ = rdb.Close()
+		return nil, fmt.Errorf("redis connection failed: %w", err)
+	}
 
-	for i := 1; i <= maxFails; i++ {
-		log.Infof("🔄 Attempting to connect to Redis %d/%d...", i, maxFails)
-		err := rdb.Ping(ctx).Err()
+	log.Warn("⚠️ Redis not reachable, reconnecting in background...")
+	go adapter.reconnect(ctx, backoff)
+	return adapter, nil
+}
+
+// reconnect 연결될 때까지 backoff 로 ping. 그동안 요청은 에러를 반환하고 health check 가 이를 보고한다
+func (r *redisAdapter) reconnect(ctx context.Context, backoff infrautil.Backoff) {
+	for attempt := 1; ; attempt++ {
+		if !backoff.Sleep(ctx, attempt) {
+			return
+		}
+		err := r.client.Ping(ctx).Err()
 		if err == nil {
-			log.Infof("✅ Redis connected")
-			return &redisAdapter{
-				client: rdb,
-				ttl:    cfg.TTLSeconds,
-				log:    log,
-			}
+			r.log.Infof("✅ Redis reconnected after %d attempts", attempt)
+			return
 		}
-		failCount++
-		time.Sleep(2 * time.Second)
+		r.log.Warnf("🔄 Redis reconnect attempt %d failed: %v", attempt, err)
 	}
-
-	os.Exit(1)
-	return nil
 }
 
-func (r *redisAdapter) Get(key string) (string, error) {
-	ctx := context.Background()
+func (r *redisAdapter) Get(ctx context.Context, key string) (string, error) {
 	val, err := r.client.Get(ctx, key).Result()
 	if errors.Is(err, redis.Nil) {
-		r.log.Infof("🔍 Cache miss [key=%s]", key)
-		return "", nil
+		logger.With(ctx, r.log).Debugf("🔍 Cache miss [key=%s]", key)
+		return "", _interface.ErrNotFound
 	}
 	if err != nil {
-		r.log.Errorf("❗ Redis GET error [key=%s]: %v", key, err)
-		return "", err
+		logger.With(ctx, r.log).Errorf("❗ Redis GET error [key=%s]: %v", key, err)
+		return "", classify(err)
 	}
-	r.log.Infof("✅ Cache hit [key=%s]", key)
+	logger.With(ctx, r.log).Debugf("✅ Cache hit [key=%s]", key)
 	return val, nil
 }
 
-func (r *redisAdapter) Set(key string, value string, ttlSeconds int) error {
-	ctx := context.Background()
+func (r *redisAdapter) Set(ctx context.Context, key string, value string, ttlSeconds int) error {
 	err := r.client.Set(ctx, key, value, time.Duration(ttlSeconds)*time.Second).Err()
 	if err != nil {
-		r.log.Errorf("❗ Redis SET error [key=%s]: %v", key, err)
-		return err
+		logger.With(ctx, r.log).Errorf("❗ Redis SET error [key=%s]: %v", key, err)
+		return classify(err)
 	}
-	r.log.Infof("📌 Cache set [key=%s, ttl=%ds]", key, ttlSeconds)
+	logger.With(ctx, r.log).Debugf("📌 Cache set [key=%s, ttl=%ds]", key, ttlSeconds)
 	return nil
 }
 
-func (r *redisAdapter) Invalidate(key string) error {
-	ctx := context.Background()
+func (r *redisAdapter) Invalidate(ctx context.Context, key string) error {
 	err := r.client.Del(ctx, key).Err()
 	if err != nil {
-		r.log.Errorf("❗ Redis DEL error [key=%s]: %v", key, err)
-		return err
+		logger.With(ctx, r.log).Errorf("❗ Redis DEL error [key=%s]: %v", key, err)
+		return classify(err)
+	}
+	logger.With(ctx, r.log).Debugf("🚫 Cache invalidated [key=%s]", key)
+	return nil
+}
+
+func (r *redisAdapter) Ping(ctx context.Context) error {
+	if err := r.client.Ping(ctx).Err(); err != nil {
+		return classify(err)
 	}
-	r.log.Infof("🚫 Cache invalidated [key=%s]", key)
 	return nil
 }
+
+// classify Redis 서버가 돌려준 에러(WRONGTYPE 등)는 그대로 두고,
+// 연결 실패나 timeout 은 ErrUnavailable 로 감싼다
+func classify(err error) error {
+	var rerr redis.Error
+	if errors.As(err, &rerr) || errors.Is(err, context.Canceled) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", _interface.ErrUnavailable, err)
+}
+
+func (r *redisAdapter) Close() error {
+	r.log.Infof("🛑 Redis client closing")
+	r.stop()
+	return r.client.Close()
+}
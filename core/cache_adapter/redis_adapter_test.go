@@ -0,0 +1,50 @@
+package cache_adapter
+
+import (
+	"cache/config"
+	_interface "cache/interface"
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+// closedAddress 연결이 거부되는 로컬 주소
+func closedAddress(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := lis.Addr().String()
+	_ = lis.Close()
+	return addr
+}
+
+func unreachableRedis(t *testing.T, failFast bool) config.RedisConfig {
+	return config.RedisConfig{
+		Address: closedAddress(t),
+		Retry:   config.RetryConfig{FailFast: failFast, StartupAttempts: 2, BackoffBaseMs: 1, BackoffMaxMs: 5},
+	}
+}
+
+func TestRedisFailFastReturnsError(t *testing.T) {
+	if _, err := NewRedisAdapter(unreachableRedis(t, true)); err == nil {
+		t.Fatal("expected error for unreachable Redis with fail_fast")
+	}
+}
+
+func TestRedisStartsWithoutBackend(t *testing.T) {
+	adapter, err := NewRedisAdapter(unreachableRedis(t, false))
+	if err != nil {
+		t.Fatalf("NewRedisAdapter: %v", err)
+	}
+	defer adapter.Close()
+
+	if _, err := adapter.Get(context.Background(), "users:1"); !errors.Is(err, _interface.ErrUnavailable) {
+		t.Fatalf("Get err = %v, want ErrUnavailable", err)
+	}
+	if err := adapter.Ping(context.Background()); err == nil {
+		t.Fatal("Ping should fail while Redis is down")
+	}
+}
@@ -0,0 +1,66 @@
+package cache_adapter
+
+import (
+	_interface "cache/interface"
+	"cache/tracing"
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type tracingAdapter struct {
+	next    _interface.ICacheAdapter
+	backend string
+}
+
+// NewTracingAdapter adapter 호출마다 client span 을 남기는 데코레이터
+func NewTracingAdapter(next _interface.ICacheAdapter, backend string) _interface.ICacheAdapter {
+	return &tracingAdapter{next: next, backend: backend}
+}
+
+func (t *tracingAdapter) start(ctx context.Context, op string, key string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, t.backend+"."+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", t.backend),
+			attribute.String("db.operation", op),
+			attribute.String("cache.key", key),
+		))
+}
+
+func (t *tracingAdapter) Get(ctx context.Context, key string) (val string, err error) {
+	ctx, span := t.start(ctx, "get", key)
+	defer func() {
+		span.SetAttributes(attribute.Bool("cache.hit", err == nil))
+		if errors.Is(err, _interface.ErrNotFound) {
+			// 미스는 span 에러로 남기지 않는다
+			tracing.End(span, nil)
+			return
+		}
+		tracing.End(span, err)
+	}()
+	return t.next.Get(ctx, key)
+}
+
+func (t *tracingAdapter) Set(ctx context.Context, key string, value string, ttlSeconds int) (err error) {
+	ctx, span := t.start(ctx, "set", key)
+	span.SetAttributes(attribute.Int("cache.ttl_seconds", ttlSeconds))
+	defer func() { tracing.End(span, err) }()
+	return t.next.Set(ctx, key, value, ttlSeconds)
+}
+
+func (t *tracingAdapter) Invalidate(ctx context.Context, key string) (err error) {
+	ctx, span := t.start(ctx, "invalidate", key)
+	defer func() { tracing.End(span, err) }()
+	return t.next.Invalidate(ctx, key)
+}
+
+func (t *tracingAdapter) Ping(ctx context.Context) error {
+	return t.next.Ping(ctx)
+}
+
+func (t *tracingAdapter) Close() error {
+	return t.next.Close()
+}
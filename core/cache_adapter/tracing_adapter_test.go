@@ -0,0 +1,38 @@
+package cache_adapter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingAdapterSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(prev)
+
+	backend := newStubBackend()
+	adapter := NewTracingAdapter(backend, "redis")
+	ctx := context.Background()
+
+	_, _ = adapter.Get(ctx, "users:1") // miss
+	backend.err = errors.New("boom")
+	_ = adapter.Set(ctx, "users:1", "v", 0)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("spans = %d, want 2", len(spans))
+	}
+	if spans[0].Name() != "redis.get" || spans[0].Status().Code == codes.Error {
+		t.Fatalf("miss span = %s %v, want no error", spans[0].Name(), spans[0].Status())
+	}
+	if spans[1].Name() != "redis.set" || spans[1].Status().Code != codes.Error {
+		t.Fatalf("failed set span = %s %v, want error", spans[1].Name(), spans[1].Status())
+	}
+}
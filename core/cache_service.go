@@ -1,30 +1,151 @@
 package core
 
 import (
+	"cache/config"
+	"cache/core/cache_adapter"
 	"cache/interface"
+	"cache/tenant"
+	"cache/tracing"
+	"context"
+	"errors"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type CacheService struct {
-	cache    _interface.ICacheAdapter
+	cache _interface.ICacheAdapter
+
+	mu       sync.RWMutex
 	strategy _interface.IInvalidationStrategy
+	quota    *quotaTracker
+	quotaCfg config.QuotaConfig
+
+	tenantMu     sync.Mutex
+	tenantQuotas map[string]*quotaTracker
 }
 
 func NewCacheService(c _interface.ICacheAdapter, s _interface.IInvalidationStrategy) *CacheService {
-	return &CacheService{cache: c, strategy: s}
+	return &CacheService{
+		cache:        c,
+		strategy:     s,
+		quota:        newQuotaTracker(config.QuotaConfig{}),
+		tenantQuotas: make(map[string]*quotaTracker),
+	}
+}
+
+// SetQuota topic 별 key 수/크기 한도 설정. quota 를 따로 정하지 않은 tenant 도 이 한도를 각자 적용받는다.
+// 기존 집계는 초기화된다
+func (cs *CacheService) SetQuota(cfg config.QuotaConfig) {
+	cs.mu.Lock()
+	cs.quota = newQuotaTracker(cfg)
+	cs.quotaCfg = cfg
+	cs.mu.Unlock()
+
+	cs.tenantMu.Lock()
+	cs.tenantQuotas = make(map[string]*quotaTracker)
+	cs.tenantMu.Unlock()
 }
 
-func (cs *CacheService) Get(topic string, key string) (string, error) {
-	actualKey := cs.strategy.GenerateKey(topic, key)
-	return cs.cache.Get(actualKey)
+// SetStrategy 설정 reload 시 key 생성/TTL 전략 교체
+func (cs *CacheService) SetStrategy(s _interface.IInvalidationStrategy) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.strategy = s
+}
+
+func (cs *CacheService) currentStrategy() _interface.IInvalidationStrategy {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	return cs.strategy
+}
+
+// quotaFor ctx 에 tenant 가 있으면 tenant 전용 quota, 없으면 전역 quota
+func (cs *CacheService) quotaFor(ctx context.Context) *quotaTracker {
+	t, ok := tenant.From(ctx)
+	if !ok {
+		cs.mu.RLock()
+		defer cs.mu.RUnlock()
+		return cs.quota
+	}
+
+	cs.tenantMu.Lock()
+	defer cs.tenantMu.Unlock()
+	q, ok := cs.tenantQuotas[t.ID]
+	if !ok {
+		cfg := t.Quota
+		if cfg.Default == (config.TopicQuota{}) && len(cfg.Topics) == 0 {
+			cs.mu.RLock()
+			cfg = cs.quotaCfg
+			cs.mu.RUnlock()
+		}
+		q = newQuotaTracker(cfg)
+		cs.tenantQuotas[t.ID] = q
+	}
+	return q
 }
 
-func (cs *CacheService) Set(topic string, key string, val string, ttl int) error {
-	actualKey := cs.strategy.GenerateKey(topic, key)
-	ttl = cs.strategy.ComputeTTL(ttl)
-	return cs.cache.Set(actualKey, val, ttl)
+// key strategy 가 만든 key 에 tenant prefix 적용
+func (cs *CacheService) key(ctx context.Context, topic string, key string) string {
+	actualKey := cs.currentStrategy().GenerateKey(topic, key)
+	if t, ok := tenant.From(ctx); ok {
+		return t.Key(actualKey)
+	}
+	return actualKey
+}
+
+func (cs *CacheService) Get(ctx context.Context, topic string, key string) (val string, err error) {
+	ctx, span := startSpan(ctx, "CacheService.Get", topic, key)
+	defer func() {
+		if errors.Is(err, _interface.ErrNotFound) {
+			// 미스는 span 에러로 남기지 않는다
+			tracing.End(span, nil)
+			return
+		}
+		tracing.End(span, err)
+	}()
+	ctx = cache_adapter.WithTopic(ctx, topic)
+
+	return cs.cache.Get(ctx, cs.key(ctx, topic, key))
+}
+
+func (cs *CacheService) Set(ctx context.Context, topic string, key string, val string, ttl int) (err error) {
+	ctx, span := startSpan(ctx, "CacheService.Set", topic, key)
+	defer func() { tracing.End(span, err) }()
+	ctx = cache_adapter.WithTopic(ctx, topic)
+
+	actualKey := cs.key(ctx, topic, key)
+	if t, ok := tenant.From(ctx); ok {
+		ttl = t.TTL(topic, ttl)
+	}
+	ttl = cs.currentStrategy().ComputeTTL(topic, ttl)
+
+	undo, err := cs.quotaFor(ctx).reserve(topic, key, int64(len(val)), ttl)
+	if err != nil {
+		return err
+	}
+	if err = cs.cache.Set(ctx, actualKey, val, ttl); err != nil {
+		undo()
+	}
+	return err
+}
+
+func (cs *CacheService) Invalidate(ctx context.Context, topic string, key string) (err error) {
+	ctx, span := startSpan(ctx, "CacheService.Invalidate", topic, key)
+	defer func() { tracing.End(span, err) }()
+	ctx = cache_adapter.WithTopic(ctx, topic)
+
+	if err = cs.cache.Invalidate(ctx, cs.key(ctx, topic, key)); err != nil {
+		return err
+	}
+	cs.quotaFor(ctx).release(topic, key)
+	return nil
 }
 
-func (cs *CacheService) Invalidate(topic string, key string) error {
-	actualKey := cs.strategy.GenerateKey(topic, key)
-	return cs.cache.Invalidate(actualKey)
+func startSpan(ctx context.Context, name string, topic string, key string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, name, trace.WithAttributes(
+		attribute.String("cache.topic", topic),
+		attribute.String("cache.key", key),
+	))
 }
@@ -0,0 +1,101 @@
+package core
+
+import (
+	"cache/config"
+	"cache/core/cache_adapter"
+	"cache/core/strategy"
+	_interface "cache/interface"
+	"cache/tenant"
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newEncryptedService(t *testing.T) (*CacheService, _interface.ICacheAdapter) {
+	t.Helper()
+	t.Setenv("TEST_CACHE_KEYS", "k1="+base64.StdEncoding.EncodeToString(make([]byte, 32)))
+
+	memory := cache_adapter.NewMemoryAdapter(config.MemoryConfig{})
+	encrypted, err := cache_adapter.NewEncryptedAdapter(memory, config.EncryptionConfig{
+		Enabled:     true,
+		Topics:      []string{"pii"},
+		ActiveKeyID: "k1",
+		KeyEnv:      "TEST_CACHE_KEYS",
+	})
+	if err != nil {
+		t.Fatalf("NewEncryptedAdapter: %v", err)
+	}
+	s := strategy.NewVersionedKeyStrategy(config.InvalidationConfig{})
+	return NewCacheService(encrypted, s), memory
+}
+
+func TestEncryptsTenantScopedTopics(t *testing.T) {
+	resolver := tenant.NewResolver(config.TenancyConfig{
+		Enabled: true,
+		Tenants: []config.TenantConfig{{ID: "acme"}, {ID: "globex", KeyPrefix: "globex/"}},
+	})
+
+	for _, id := range []string{"acme", "globex"} {
+		t.Run(id, func(t *testing.T) {
+			cs, memory := newEncryptedService(t)
+			tn, _ := resolver.Lookup(id)
+			ctx := tenant.WithContext(context.Background(), tn)
+
+			if err := cs.Set(ctx, "pii", "user-1", "alice@example.com", 60); err != nil {
+				t.Fatalf("Set: %v", err)
+			}
+			stored, err := memory.Get(ctx, cs.key(ctx, "pii", "user-1"))
+			if err != nil {
+				t.Fatalf("raw Get: %v", err)
+			}
+			if !strings.HasPrefix(stored, "enc:k1:") || strings.Contains(stored, "alice") {
+				t.Fatalf("stored value is not ciphertext: %q", stored)
+			}
+
+			got, err := cs.Get(ctx, "pii", "user-1")
+			if err != nil || got != "alice@example.com" {
+				t.Fatalf("Get = %q, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestDoesNotEncryptOtherTopics(t *testing.T) {
+	cs, memory := newEncryptedService(t)
+	ctx := context.Background()
+
+	if err := cs.Set(ctx, "public", "k", "plain", 60); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	stored, err := memory.Get(ctx, cs.key(ctx, "public", "k"))
+	if err != nil || stored != "plain" {
+		t.Fatalf("stored = %q, %v", stored, err)
+	}
+}
+
+func TestGetMissIsNotSpanError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	svc := newTestService()
+	if _, err := svc.Get(context.Background(), "users", "missing"); !errors.Is(err, _interface.ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Name() != "CacheService.Get" {
+		t.Fatalf("spans = %v, want one CacheService.Get span", spans)
+	}
+	if spans[0].Status().Code == codes.Error {
+		t.Fatalf("miss recorded as span error: %v", spans[0].Status())
+	}
+}
@@ -5,8 +5,11 @@ import (
 	"cache/infrautil"
 	_interface "cache/interface"
 	"cache/logger"
+	"cache/tenant"
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -16,57 +19,139 @@ import (
 )
 
 type kafkaBroker struct {
-	writers map[string]*kafka.Writer
-	reader  *kafka.Reader
-	log     *zap.SugaredLogger
-	brokers []string
-	lock    sync.RWMutex
+	writers   map[string]*kafka.Writer // 발행용 writer. retry/DLQ writer 는 섞지 않는다
+	group     *groupConsumer
+	groupID   string
+	readerCfg config.KafkaReaderConfig
+	log       *zap.SugaredLogger
+	brokers   []string
+	topics    []string
+	consumer  *consumerTracker
+	suppress  *infrautil.SuppressLogger
+	backoff   infrautil.Backoff
+	retries   int
+
+	checkpoint *checkpointStore
+	onCommit   func([]kafka.Message)
+
+	dlq         config.DeadLetterConfig
+	retryReader *kafka.Reader
+	retryWriter *kafka.Writer
+	dlqWriter   *kafka.Writer
+	lock        sync.RWMutex
+
+	subCtx   context.Context
+	handler  _interface.MessageHandler
+	stopLoop context.CancelFunc
+	loopWg   sync.WaitGroup
+
+	feedCtx     context.Context
+	feedHandler _interface.MessageHandler
+	stopFeed    context.CancelFunc
+
+	topicMu sync.Mutex // AddTopics 직렬화
 }
 
-func NewKafkaBroker(cfg config.KafkaConfig) _interface.IEventBroker {
+// groupConsumer consumer group reader 와 그 offset committer. topic 이 바뀌면 통째로 교체한다
+type groupConsumer struct {
+	reader *kafka.Reader
+	commit *offsetCommitter
+	stop   context.CancelFunc
+	done   chan struct{}
+}
+
+// NewKafkaBroker startup 연결 확인이 실패하면 fail_fast 일 때만 에러를 반환한다.
+// 그 외에는 reader/writer 가 background 에서 재연결한다.
+func NewKafkaBroker(cfg config.KafkaConfig) (_interface.IEventBroker, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("kafka brokers not configured")
+	}
 	log := logger.Logger
+	consumer := newConsumerTracker(cfg.GroupID)
+
+	k := &kafkaBroker{
+		writers:   make(map[string]*kafka.Writer),
+		log:       log,
+		brokers:   cfg.Brokers,
+		topics:    cfg.Topics,
+		groupID:   cfg.GroupID,
+		consumer:  consumer,
+		suppress:  infrautil.NewSuppressLogger(),
+		backoff:   infrautil.NewBackoff(cfg.Retry),
+		retries:   max(cfg.Reader.HandlerRetries, 0),
+		readerCfg: cfg.Reader,
+		dlq:       withDeadLetterDefaults(cfg.DeadLetter, cfg.GroupID),
+	}
+
+	err := infrautil.Retry(context.Background(), k.backoff, cfg.Retry.StartupAttempts, func(attempt int) error {
+		log.Infof("🔄 Attempting to connect to Kafka %d/%d...", attempt, max(cfg.Retry.StartupAttempts, 1))
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		return k.Ping(ctx)
+	})
+	infrautil.LogConnectionResult(log, "Kafka", err)
+	if err != nil {
+		if cfg.Retry.FailFast {
+			return nil, fmt.Errorf("kafka connection failed: %w", err)
+		}
+		log.Warn("⚠️ Kafka may not be ready, but continuing anyway...")
+	}
 
-	writers := make(map[string]*kafka.Writer)
 	for _, t := range cfg.Topics {
 		createTopicIfNotExists(cfg.Brokers[0], t, log)
-		writers[t] = kafkaWriter(cfg.Brokers, t)
+		k.writers[t] = kafkaWriter(cfg.Brokers, t)
 		log.Infof("🪄 Initialized writer for topic [%s] from config", t)
 	}
+	if k.dlq.Enabled {
+		createTopicIfNotExists(cfg.Brokers[0], k.dlq.RetryTopic, log)
+		createTopicIfNotExists(cfg.Brokers[0], k.dlq.Topic, log)
+		k.retryWriter = kafkaWriter(cfg.Brokers, k.dlq.RetryTopic)
+		k.dlqWriter = kafkaWriter(cfg.Brokers, k.dlq.Topic)
+		k.retryReader = kafka.NewReader(kafka.ReaderConfig{
+			Brokers:     cfg.Brokers,
+			GroupID:     cfg.GroupID,
+			Topic:       k.dlq.RetryTopic,
+			MaxWait:     time.Duration(cfg.Reader.MaxWaitMs) * time.Millisecond,
+			ErrorLogger: kafka.LoggerFunc(log.Debugf),
+		})
+	}
+
+	if path := cfg.Reader.Replay.CheckpointFile; path != "" {
+		if k.checkpoint, err = loadCheckpoint(path); err != nil {
+			log.Warnf("⚠️ Kafka checkpoint ignored: %v", err)
+		}
+		k.onCommit = func(msgs []kafka.Message) {
+			if err := k.checkpoint.update(msgs); err != nil {
+				log.Errorf("❗ Kafka checkpoint write failed: %v", err)
+			}
+		}
+	}
+	k.group = k.newGroupConsumer(cfg.Topics)
+
+	return k, nil
+}
 
-	suppress := infrautil.NewSuppressLogger()
+func (k *kafkaBroker) newGroupConsumer(topics []string) *groupConsumer {
 	reader := kafka.NewReader(kafka.ReaderConfig{
-		Brokers:       cfg.Brokers,
-		GroupID:       cfg.GroupID,
-		GroupTopics:   cfg.Topics,
-		MinBytes:      cfg.Reader.MinBytes,
-		MaxBytes:      cfg.Reader.MaxBytes,
-		MaxWait:       time.Duration(cfg.Reader.MaxWaitMs) * time.Millisecond,
-		QueueCapacity: cfg.Reader.QueueCapacity,
-		ErrorLogger:   kafka.LoggerFunc(func(string, ...interface{}) {}),
+		Brokers:       k.brokers,
+		GroupID:       k.groupID,
+		GroupTopics:   topics,
+		MinBytes:      k.readerCfg.MinBytes,
+		MaxBytes:      k.readerCfg.MaxBytes,
+		MaxWait:       time.Duration(k.readerCfg.MaxWaitMs) * time.Millisecond,
+		QueueCapacity: k.readerCfg.QueueCapacity,
+		ErrorLogger:   kafka.LoggerFunc(k.consumer.observeError),
 		Logger: kafka.LoggerFunc(func(msg string, args ...interface{}) {
-			if !suppress.ShouldLog(msg, 10*time.Second) {
+			k.consumer.observe(msg, args...)
+			if !k.suppress.ShouldLog(msg, 10*time.Second) {
 				return
 			}
-			log.Debugf("[Kafka] "+msg, args...)
+			k.log.Debugf("[Kafka] "+msg, args...)
 		}),
 	})
-
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-	defer cancel()
-
-	// Try reading one message just to confirm connectivity, but do not enforce it harshly.
-	_, err := reader.FetchMessage(ctx)
-	infrautil.LogConnectionResult(log, "Kafka", err)
-	if err != nil {
-		log.Warn("⚠️ Kafka may not be ready, but continuing anyway...")
-		// do not os.Exit(1)
-	}
-
-	return &kafkaBroker{
-		writers: writers,
-		reader:  reader,
-		log:     log,
-		brokers: cfg.Brokers,
+	return &groupConsumer{
+		reader: reader,
+		commit: newOffsetCommitter(reader, k.readerCfg, k.log, k.onCommit),
 	}
 }
 
@@ -124,70 +209,336 @@ func createTopicIfNotExists(broker string, topic string, log *zap.SugaredLogger)
 	}
 }
 
-func (k *kafkaBroker) Publish(topic string, key string) error {
-	return k.PublishTo(k.defaultTopic(), key)
+// Publish tenant 에 전용 Kafka topic 이 있으면 그곳으로, 없으면 기본 Kafka topic 으로 발행.
+// 캐시 topic 은 헤더로 전달한다
+func (k *kafkaBroker) Publish(ctx context.Context, topic string, key string) error {
+	return k.publishTo(ctx, k.Destination(ctx, topic), topic, key)
+}
+
+// Destination 캐시 topic 의 메시지를 발행할 Kafka topic. tenant 전용 topic 이 있으면 그쪽, 없으면 기본 topic
+func (k *kafkaBroker) Destination(ctx context.Context, _ string) string {
+	if t, ok := tenant.From(ctx); ok && t.KafkaTopic != "" {
+		return t.KafkaTopic
+	}
+	return k.defaultTopic()
 }
 
+// defaultTopic 설정의 첫 번째 topic. map 순회 순서에 의존하지 않도록 설정 순서를 따른다
 func (k *kafkaBroker) defaultTopic() string {
 	k.lock.RLock()
-	for t := range k.writers {
-		k.lock.RUnlock()
-		return t
+	defer k.lock.RUnlock()
+	if len(k.topics) > 0 {
+		return k.topics[0]
 	}
-	k.lock.RUnlock()
 	return "default"
 }
 
-func (k *kafkaBroker) PublishTo(topic string, key string) error {
+// publishTo destination Kafka topic 으로 발행. 같은 캐시 key 는 같은 partition 으로 가도록 message key 를 topic:key 로 둔다
+func (k *kafkaBroker) publishTo(ctx context.Context, destination string, topic string, key string) error {
+	writer := k.writerFor(destination)
+	msg := kafka.Message{
+		Key:   []byte(topic + ":" + key),
+		Value: []byte(key),
+	}
+	injectHeaders(ctx, &msg.Headers)
+	kafkaHeaderCarrier{headers: &msg.Headers}.Set(headerCacheTopic, topic)
+	err := writer.WriteMessages(ctx, msg)
+	log := logger.With(ctx, k.log)
+	if err != nil {
+		log.Errorf("🔥 Kafka publish error [kafka_topic=%s, topic=%s, key=%s]: %v", destination, topic, key, err)
+	} else {
+		log.Debugf("📤 Kafka message sent [kafka_topic=%s, topic=%s, key=%s]", destination, topic, key)
+	}
+	return err
+}
+
+// writerFor topic 별 writer, 없으면 생성
+func (k *kafkaBroker) writerFor(topic string) *kafka.Writer {
 	k.lock.RLock()
 	writer, ok := k.writers[topic]
 	k.lock.RUnlock()
+	if ok {
+		return writer
+	}
 
-	if !ok {
-		k.lock.Lock()
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if writer, ok = k.writers[topic]; !ok {
 		writer = kafkaWriter(k.brokers, topic)
 		k.writers[topic] = writer
-		k.lock.Unlock()
 		k.log.Infof("🪄 Created new writer for topic [%s]", topic)
 	}
+	return writer
+}
 
-	msg := kafka.Message{
-		Key:   []byte(key),
-		Value: []byte(key),
+func (k *kafkaBroker) Subscribe(ctx context.Context, handler _interface.MessageHandler) error {
+	ctx, cancel := context.WithCancel(ctx)
+	k.lock.Lock()
+	k.subCtx = ctx
+	k.handler = handler
+	k.stopLoop = cancel
+	group := k.group
+	k.lock.Unlock()
+
+	// 첫 health check 전에 replay 중임을 표시해 readiness 가 먼저 green 이 되지 않게 한다
+	k.consumer.setReplaying(k.replayEnabled())
+	k.startGroup(ctx, handler, group, true)
+	if k.retryReader != nil {
+		k.loopWg.Add(1)
+		go func() {
+			defer k.loopWg.Done()
+			k.consumeRetries(ctx, handler)
+		}()
 	}
-	err := writer.WriteMessages(context.Background(), msg)
-	if err != nil {
-		k.log.Errorf("🔥 Kafka publish error [topic=%s, key=%s]: %v", topic, key, err)
-	} else {
-		k.log.Infof("📤 Kafka message sent [topic=%s, key=%s]", topic, key)
+	return nil
+}
+
+// startGroup group reader 소비 시작. stop 으로 이 reader 의 소비만 멈출 수 있다
+func (k *kafkaBroker) startGroup(parent context.Context, handler _interface.MessageHandler, g *groupConsumer, replay bool) {
+	ctx, cancel := context.WithCancel(parent)
+	g.stop = cancel
+	g.done = make(chan struct{})
+
+	k.loopWg.Add(1)
+	go func() {
+		defer k.loopWg.Done()
+		defer close(g.done)
+		if replay {
+			k.replay(ctx, handler)
+		}
+		k.consume(ctx, handler, g)
+	}()
+}
+
+// AddTopics 새 topic 을 만들고 writer 를 추가한 뒤 GroupTopics 를 갱신한 reader 로 교체한다.
+// 기존 reader 는 처리 중인 메시지의 offset 을 commit 한 뒤 닫히며, 교체 시 group rebalance 가 한 번 일어난다
+func (k *kafkaBroker) AddTopics(topics []string) error {
+	k.topicMu.Lock()
+	defer k.topicMu.Unlock()
+
+	k.lock.RLock()
+	current := append([]string(nil), k.topics...)
+	k.lock.RUnlock()
+
+	var added []string
+	for _, t := range topics {
+		if t != "" && !slices.Contains(current, t) && !slices.Contains(added, t) {
+			added = append(added, t)
+		}
 	}
-	return err
+	if len(added) == 0 {
+		return nil
+	}
+
+	for _, t := range added {
+		createTopicIfNotExists(k.brokers[0], t, k.log)
+		k.writerFor(t)
+	}
+	all := append(current, added...)
+	next := k.newGroupConsumer(all)
+
+	k.lock.Lock()
+	prev := k.group
+	k.group = next
+	k.topics = all
+	ctx, handler := k.subCtx, k.handler
+	feedCtx, feedHandler := k.feedCtx, k.feedHandler
+	k.lock.Unlock()
+
+	if feedHandler != nil {
+		for _, t := range added {
+			k.startFeed(feedCtx, feedHandler, t)
+		}
+	}
+
+	if prev.stop != nil {
+		prev.stop()
+		<-prev.done
+	}
+	if err := prev.reader.Close(); err != nil {
+		k.log.Warnf("Kafka reader close error during topic update: %v", err)
+	}
+	if handler != nil {
+		k.startGroup(ctx, handler, next, false)
+	}
+	k.log.Infof("🪄 Kafka topics added %v, now consuming %v", added, all)
+	return nil
 }
 
-func (k *kafkaBroker) Subscribe(handler func(topic string, key string)) error {
-	ctx := context.Background()
-	go infrautil.RunMessageLoop(ctx, k.log, 5, func() (string, error) {
-		m, err := k.reader.ReadMessage(ctx)
-		return string(m.Value), err
-	}, func(msg string) {
-		topic := k.reader.Config().Topic
-		handler(topic, msg)
+// consume FetchMessage 로 읽고 handler 가 성공한 뒤에 offset 을 commit 한다 (at-least-once).
+// 처리는 worker pool 에서 병렬로 하되 같은 key 는 같은 worker 가 순서대로 처리한다.
+func (k *kafkaBroker) consume(ctx context.Context, handler _interface.MessageHandler, g *groupConsumer) {
+	defer g.commit.close()
+
+	pool := infrautil.NewKeyedPool(k.readerCfg.Workers, k.readerCfg.WorkerQueueSize, func(d delivery) {
+		k.handle(ctx, handler, g.commit, d)
 	})
-	return nil
+	// 종료 시 대기열에 남은 메시지까지 처리한 뒤 commit
+	defer pool.Close()
+
+	infrautil.RunMessageLoop(ctx, k.log, k.backoff, func() (delivery, error) {
+		m, err := g.reader.FetchMessage(ctx)
+		if err != nil {
+			return delivery{}, err
+		}
+		g.commit.track(m)
+		return newDelivery(ctx, m), nil
+	}, func(d delivery) {
+		pool.Submit(d.msg.String(), d)
+	}, k.consumer.observeRead)
+}
+
+// handle 재시도를 모두 소진하면 retry topic 으로 넘기고 (dead_letter 비활성 시 기록만 남기고)
+// commit 해 partition 이 막히지 않게 한다. 종료 중이면 commit 하지 않아 재시작 후 다시 전달된다.
+func (k *kafkaBroker) handle(ctx context.Context, handler _interface.MessageHandler, commit *offsetCommitter, d delivery) {
+	attempts, err := k.process(ctx, handler, d)
+	if err != nil {
+		if ctx.Err() != nil {
+			k.log.Warnf("🛑 Message %s left uncommitted for redelivery", d.msg)
+			return
+		}
+		if k.dlq.Enabled {
+			if !k.deadLetter(ctx, d.raw, envelope{topic: d.raw.Topic, cacheTopic: d.msg.Topic}, attempts, err) {
+				return
+			}
+		} else {
+			k.log.Errorf("❌ Giving up on message %s [partition=%d, offset=%d] after %d attempts: %v",
+				d.msg, d.raw.Partition, d.raw.Offset, attempts, err)
+		}
+	}
+	commit.ack(d.raw)
+}
+
+// process 실패하면 backoff 후 최대 retries 회 재시도. 시도 횟수와 마지막 에러를 반환
+func (k *kafkaBroker) process(ctx context.Context, handler _interface.MessageHandler, d delivery) (int, error) {
+	for attempt := 1; ; attempt++ {
+		err := handler(d.ctx, d.msg)
+		if err == nil {
+			return attempt, nil
+		}
+		if attempt > k.retries {
+			return attempt, err
+		}
+		logger.With(d.ctx, k.log).Warnf("🔁 Message %s handling failed (attempt %d/%d): %v", d.msg, attempt, k.retries+1, err)
+		if !k.backoff.Sleep(ctx, attempt) {
+			return attempt, err
+		}
+	}
+}
+
+// delivery 수신한 메시지와 헤더에서 복원한 context
+type delivery struct {
+	raw kafka.Message
+	ctx context.Context
+	msg _interface.Message
+}
+
+// newDelivery 발행 측 trace context 와 request ID 를 이어받는다.
+// 캐시 topic 헤더가 없는 이전 버전 메시지는 Kafka topic 을 캐시 topic 으로 본다
+func newDelivery(ctx context.Context, m kafka.Message) delivery {
+	c := kafkaHeaderCarrier{headers: &m.Headers}
+	topic := c.Get(headerCacheTopic)
+	if topic == "" {
+		topic = m.Topic
+	}
+	return delivery{
+		raw: m,
+		ctx: extractContext(ctx, &m.Headers),
+		msg: _interface.Message{
+			Topic:       topic,
+			Key:         string(m.Value),
+			Tenant:      c.Get(headerTenant),
+			Timestamp:   m.Time,
+			Destination: m.Topic,
+		},
+	}
+}
+
+func (d delivery) String() string {
+	return d.msg.String()
+}
+
+// Ping 설정된 broker 중 하나라도 연결되면 정상
+func (k *kafkaBroker) Ping(ctx context.Context) error {
+	var lastErr error
+	for _, addr := range k.brokers {
+		conn, err := kafka.DialContext(ctx, "tcp", addr)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		return conn.Close()
+	}
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no kafka brokers configured")
+	}
+	return lastErr
+}
+
+func (k *kafkaBroker) ConsumerStatus() _interface.ConsumerStatus {
+	return k.consumer.snapshot()
+}
+
+// ConsumerLag reader 가 마지막으로 관측한 consumer lag (high watermark - offset)
+func (k *kafkaBroker) ConsumerLag() int64 {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	return k.group.reader.Stats().Lag
 }
 
 func (k *kafkaBroker) Close() error {
 	k.log.Infof("🛑 Kafka broker closing")
-	if err := k.reader.Close(); err != nil {
+
+	k.lock.RLock()
+	stop := k.stopLoop
+	stopFeed := k.stopFeed
+	k.lock.RUnlock()
+	if stop != nil {
+		stop()
+	}
+	if stopFeed != nil {
+		stopFeed()
+	}
+	// feed 와 처리 중인 메시지, 남은 offset commit 이 끝난 뒤 reader 를 닫는다
+	k.loopWg.Wait()
+
+	var errs []error
+	k.lock.RLock()
+	reader := k.group.reader
+	k.lock.RUnlock()
+	if err := reader.Close(); err != nil {
 		k.log.Errorf("Kafka reader close error: %v", err)
-		return err
+		errs = append(errs, err)
+	}
+	if k.retryReader != nil {
+		if err := k.retryReader.Close(); err != nil {
+			k.log.Errorf("Kafka retry reader close error: %v", err)
+			errs = append(errs, err)
+		}
+	}
+
+	for _, writer := range []*kafka.Writer{k.retryWriter, k.dlqWriter} {
+		if writer == nil {
+			continue
+		}
+		if err := writer.Close(); err != nil {
+			k.log.Errorf("Kafka writer close error for topic [%s]: %v", writer.Topic, err)
+			errs = append(errs, err)
+		}
 	}
+
+	k.lock.Lock()
+	defer k.lock.Unlock()
 	for topic, writer := range k.writers {
+		// Close 는 대기 중인 메시지를 flush 한다
 		if err := writer.Close(); err != nil {
 			k.log.Errorf("Kafka writer close error for topic [%s]: %v", topic, err)
-			return err
+			errs = append(errs, err)
 		}
 	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
 	k.log.Infof("✅ Kafka broker closed")
 	return nil
 }
@@ -0,0 +1,43 @@
+package event_broker
+
+import (
+	"cache/requestid"
+	"context"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestNewDeliveryUsesCacheTopicHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []kafka.Header
+		want    string
+	}{
+		{"header", []kafka.Header{{Key: headerCacheTopic, Value: []byte("users")}}, "users"},
+		{"legacy message without header", nil, "cache-a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newDelivery(context.Background(), kafka.Message{Topic: "cache-a", Value: []byte("42"), Headers: tt.headers})
+			if d.msg.Topic != tt.want || d.msg.Key != "42" {
+				t.Fatalf("msg = %+v, want topic %q key 42", d.msg, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDeliveryRestoresTenantAndRequestID(t *testing.T) {
+	m := kafka.Message{Topic: "cache-a", Value: []byte("42"), Headers: []kafka.Header{
+		{Key: headerCacheTopic, Value: []byte("users")},
+		{Key: headerTenant, Value: []byte("team-a")},
+		{Key: headerRequestID, Value: []byte("req-1")},
+	}}
+	d := newDelivery(context.Background(), m)
+	if d.msg.Tenant != "team-a" {
+		t.Fatalf("tenant = %q, want team-a", d.msg.Tenant)
+	}
+	if got := requestid.From(d.ctx); got != "req-1" {
+		t.Fatalf("request id = %q, want req-1", got)
+	}
+}
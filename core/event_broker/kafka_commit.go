@@ -0,0 +1,172 @@
+package event_broker
+
+import (
+	"cache/config"
+	"cache/metrics"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+const commitTimeout = 5 * time.Second
+
+type partitionKey struct {
+	topic string
+	id    int
+}
+
+type inflight struct {
+	msg  kafka.Message
+	done bool
+}
+
+// offsetCommitter 처리가 끝난 메시지의 offset 을 모아 배치로 commit 한다.
+// 병렬 처리 중에도 partition 별로 앞선 메시지가 모두 끝난 offset 까지만 commit 한다.
+type offsetCommitter struct {
+	reader   *kafka.Reader
+	log      *zap.SugaredLogger
+	batch    int
+	interval time.Duration
+	onCommit func([]kafka.Message)
+
+	mu       sync.Mutex
+	inflight map[partitionKey][]inflight
+	ready    map[partitionKey]kafka.Message
+	acked    int
+
+	kick    chan struct{}
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+// newOffsetCommitter onCommit 은 commit 이 성공한 partition 별 마지막 메시지로 호출된다 (nil 가능)
+func newOffsetCommitter(reader *kafka.Reader, cfg config.KafkaReaderConfig, log *zap.SugaredLogger, onCommit func([]kafka.Message)) *offsetCommitter {
+	c := &offsetCommitter{
+		reader:   reader,
+		log:      log,
+		batch:    cfg.CommitBatchSize,
+		interval: time.Duration(cfg.CommitIntervalMs) * time.Millisecond,
+		onCommit: onCommit,
+		inflight: make(map[partitionKey][]inflight),
+		ready:    make(map[partitionKey]kafka.Message),
+		kick:     make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	if c.batch <= 0 {
+		c.batch = 100
+	}
+	if c.interval <= 0 {
+		c.interval = time.Second
+	}
+	go c.run()
+	return c
+}
+
+// track 수신 순서대로 호출해 처리 중인 메시지로 등록
+func (c *offsetCommitter) track(m kafka.Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p := partitionKey{m.Topic, m.Partition}
+	c.inflight[p] = append(c.inflight[p], inflight{msg: m})
+}
+
+// ack 처리 완료 표시. 앞선 메시지가 모두 끝났으면 commit 대상이 된다
+func (c *offsetCommitter) ack(m kafka.Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p := partitionKey{m.Topic, m.Partition}
+	pending := c.inflight[p]
+	for i := range pending {
+		if pending[i].msg.Offset == m.Offset {
+			pending[i].done = true
+			break
+		}
+	}
+
+	n := 0
+	for n < len(pending) && pending[n].done {
+		c.ready[p] = pending[n].msg
+		n++
+	}
+	c.inflight[p] = pending[n:]
+	c.acked += n
+
+	if c.acked >= c.batch {
+		select {
+		case c.kick <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// close 남은 offset 을 commit 한 뒤 반환
+func (c *offsetCommitter) close() {
+	close(c.stop)
+	<-c.stopped
+}
+
+func (c *offsetCommitter) run() {
+	defer close(c.stopped)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			c.commit()
+			return
+		case <-c.kick:
+			c.commit()
+		case <-ticker.C:
+			c.commit()
+		}
+	}
+}
+
+// commit 실패하면 offset 을 되돌려 다음 commit 때 다시 시도
+func (c *offsetCommitter) commit() {
+	c.mu.Lock()
+	if len(c.ready) == 0 {
+		c.mu.Unlock()
+		return
+	}
+	ready := c.ready
+	count := c.acked
+	c.ready = make(map[partitionKey]kafka.Message)
+	c.acked = 0
+	c.mu.Unlock()
+
+	msgs := make([]kafka.Message, 0, len(ready))
+	for _, m := range ready {
+		msgs = append(msgs, m)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), commitTimeout)
+	defer cancel()
+
+	if err := c.reader.CommitMessages(ctx, msgs...); err != nil {
+		metrics.BrokerCommitErrors.Inc()
+		c.log.Errorf("❗ Kafka offset commit failed (%d messages): %v", count, err)
+
+		c.mu.Lock()
+		for p, m := range ready {
+			if cur, ok := c.ready[p]; !ok || cur.Offset < m.Offset {
+				c.ready[p] = m
+			}
+		}
+		c.acked += count
+		c.mu.Unlock()
+		return
+	}
+	metrics.BrokerCommits.Inc()
+	if c.onCommit != nil {
+		c.onCommit(msgs)
+	}
+	c.log.Debugf("✅ Kafka offsets committed (%d messages)", count)
+}
@@ -0,0 +1,79 @@
+package event_broker
+
+import (
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func newTestCommitter() *offsetCommitter {
+	return &offsetCommitter{
+		batch:    100,
+		inflight: make(map[partitionKey][]inflight),
+		ready:    make(map[partitionKey]kafka.Message),
+		kick:     make(chan struct{}, 1),
+	}
+}
+
+func msgAt(partition int, offset int64) kafka.Message {
+	return kafka.Message{Topic: "cache", Partition: partition, Offset: offset}
+}
+
+func TestCommitterWaitsForEarlierMessages(t *testing.T) {
+	c := newTestCommitter()
+	for offset := int64(10); offset < 13; offset++ {
+		c.track(msgAt(0, offset))
+	}
+	p := partitionKey{"cache", 0}
+
+	// 뒤 메시지가 먼저 끝나도 앞 메시지가 끝나기 전에는 commit 하지 않는다
+	c.ack(msgAt(0, 12))
+	if _, ok := c.ready[p]; ok {
+		t.Fatal("offset 12 is ready while 10 is still in flight")
+	}
+	c.ack(msgAt(0, 10))
+	if got := c.ready[p].Offset; got != 10 {
+		t.Fatalf("ready offset = %d, want 10", got)
+	}
+	c.ack(msgAt(0, 11))
+	if got := c.ready[p].Offset; got != 12 {
+		t.Fatalf("ready offset = %d, want 12", got)
+	}
+	if len(c.inflight[p]) != 0 || c.acked != 3 {
+		t.Fatalf("inflight = %v, acked = %d", c.inflight[p], c.acked)
+	}
+}
+
+func TestCommitterTracksPartitionsIndependently(t *testing.T) {
+	c := newTestCommitter()
+	c.track(msgAt(0, 1))
+	c.track(msgAt(1, 5))
+
+	c.ack(msgAt(1, 5))
+	if _, ok := c.ready[partitionKey{"cache", 0}]; ok {
+		t.Fatal("partition 0 should not be ready")
+	}
+	if got := c.ready[partitionKey{"cache", 1}].Offset; got != 5 {
+		t.Fatalf("partition 1 ready offset = %d, want 5", got)
+	}
+}
+
+func TestCommitterKicksAtBatchSize(t *testing.T) {
+	c := newTestCommitter()
+	c.batch = 2
+	c.track(msgAt(0, 1))
+	c.track(msgAt(0, 2))
+
+	c.ack(msgAt(0, 1))
+	select {
+	case <-c.kick:
+		t.Fatal("kicked before the batch was full")
+	default:
+	}
+	c.ack(msgAt(0, 2))
+	select {
+	case <-c.kick:
+	default:
+		t.Fatal("no commit kick after a full batch")
+	}
+}
@@ -0,0 +1,70 @@
+package event_broker
+
+import (
+	_interface "cache/interface"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// consumerTracker kafka-go 의 consumer group 로그와 수신 결과로부터 consumer 상태를 추적
+type consumerTracker struct {
+	mu     sync.RWMutex
+	status _interface.ConsumerStatus
+}
+
+func newConsumerTracker(groupID string) *consumerTracker {
+	return &consumerTracker{status: _interface.ConsumerStatus{GroupID: groupID}}
+}
+
+// observe kafka-go Logger 메시지 처리
+func (g *consumerTracker) observe(msg string, args ...interface{}) {
+	switch {
+	case strings.HasPrefix(msg, "Joined group") && len(args) >= 2:
+		g.mu.Lock()
+		g.status.MemberID = fmt.Sprint(args[1])
+		g.status.Joined = true
+		g.status.Error = ""
+		g.mu.Unlock()
+	case strings.HasPrefix(msg, "Leaving group"):
+		g.mu.Lock()
+		g.status.Joined = false
+		g.mu.Unlock()
+	}
+}
+
+// observeError kafka-go ErrorLogger 메시지 처리
+func (g *consumerTracker) observeError(msg string, args ...interface{}) {
+	if strings.HasPrefix(msg, "Failed to join group") ||
+		strings.HasPrefix(msg, "Unable to establish connection to consumer group coordinator") ||
+		strings.HasPrefix(msg, "Failed to sync group") {
+		g.mu.Lock()
+		g.status.Joined = false
+		g.status.Error = fmt.Sprintf(msg, args...)
+		g.mu.Unlock()
+	}
+}
+
+// observeRead 메시지 수신 루프 상태 처리
+func (g *consumerTracker) observeRead(failures int, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.status.ReadFailures = failures
+	g.status.ReadError = ""
+	if err != nil {
+		g.status.ReadError = err.Error()
+	}
+}
+
+// setReplaying 시작 시 replay 진행 여부
+func (g *consumerTracker) setReplaying(replaying bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.status.Replaying = replaying
+}
+
+func (g *consumerTracker) snapshot() _interface.ConsumerStatus {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.status
+}
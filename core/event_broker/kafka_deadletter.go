@@ -0,0 +1,339 @@
+package event_broker
+
+import (
+	"cache/config"
+	_interface "cache/interface"
+	"cache/logger"
+	"cache/metrics"
+	"cache/tenant"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+var (
+	// ErrDeadLetterDisabled dead_letter 설정이 꺼져 있을 때 DLQ 조회/재발행 에러
+	ErrDeadLetterDisabled = errors.New("dead letter queue is disabled")
+	// ErrDeadLetterNotFound 해당 위치에 메시지가 없거나 다른 tenant 의 메시지
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+)
+
+// envelope retry/DLQ topic 으로 보낼 때 헤더에 담는 처리 이력
+const (
+	headerOriginalTopic = "cache-original-topic"
+	headerAttempts      = "cache-attempts"
+	headerRound         = "cache-retry-round"
+	headerError         = "cache-error"
+	headerFailedAt      = "cache-failed-at"
+	headerRetryAt       = "cache-retry-at"
+)
+
+type envelope struct {
+	topic      string // 원래 Kafka topic
+	cacheTopic string // 무효화할 캐시 topic
+	tenant     string
+	attempts   int // 누적 handler 시도 횟수
+	round      int // retry topic 을 거친 횟수
+	err        string
+	failedAt   time.Time
+	retryAt    time.Time
+}
+
+func parseEnvelope(m kafka.Message) envelope {
+	c := kafkaHeaderCarrier{headers: &m.Headers}
+	env := envelope{topic: c.Get(headerOriginalTopic), cacheTopic: c.Get(headerCacheTopic), tenant: c.Get(headerTenant), err: c.Get(headerError)}
+	if env.topic == "" {
+		env.topic = m.Topic
+	}
+	if env.cacheTopic == "" {
+		env.cacheTopic = env.topic
+	}
+	env.attempts, _ = strconv.Atoi(c.Get(headerAttempts))
+	env.round, _ = strconv.Atoi(c.Get(headerRound))
+	if ms, err := strconv.ParseInt(c.Get(headerFailedAt), 10, 64); err == nil {
+		env.failedAt = time.UnixMilli(ms)
+	}
+	if ms, err := strconv.ParseInt(c.Get(headerRetryAt), 10, 64); err == nil {
+		env.retryAt = time.UnixMilli(ms)
+	}
+	return env
+}
+
+// message 원본의 key/value 와 trace 헤더를 유지한 채 envelope 헤더를 덮어쓴다
+func (e envelope) message(m kafka.Message) kafka.Message {
+	headers := append([]kafka.Header(nil), m.Headers...)
+	c := kafkaHeaderCarrier{headers: &headers}
+	c.Set(headerOriginalTopic, e.topic)
+	c.Set(headerCacheTopic, e.cacheTopic)
+	c.Set(headerAttempts, strconv.Itoa(e.attempts))
+	c.Set(headerRound, strconv.Itoa(e.round))
+	c.Set(headerError, e.err)
+	c.Set(headerFailedAt, strconv.FormatInt(e.failedAt.UnixMilli(), 10))
+	c.Set(headerRetryAt, strconv.FormatInt(e.retryAt.UnixMilli(), 10))
+	return kafka.Message{Key: m.Key, Value: m.Value, Headers: headers}
+}
+
+// deadLetter 처리에 실패한 메시지를 retry topic 또는 (라운드 소진 시) DLQ 로 보낸다.
+// 종료 중이라 보내지 못하면 false 를 반환하며 원본은 commit 하지 않는다.
+func (k *kafkaBroker) deadLetter(ctx context.Context, m kafka.Message, env envelope, attempts int, cause error) bool {
+	env.attempts += attempts
+	env.err = cause.Error()
+	env.failedAt = time.Now()
+	env.retryAt = env.failedAt.Add(time.Duration(k.dlq.RetryDelayMs) * time.Millisecond)
+
+	target, writer := k.dlq.RetryTopic, k.retryWriter
+	if env.round >= k.dlq.MaxRounds {
+		target, writer = k.dlq.Topic, k.dlqWriter
+	}
+	env.round++
+
+	out := env.message(m)
+	for attempt := 1; ; attempt++ {
+		err := writer.WriteMessages(ctx, out)
+		if err == nil {
+			break
+		}
+		k.log.Errorf("🔥 Failed to forward %s:%s to [%s] (attempt %d): %v", env.cacheTopic, m.Value, target, attempt, err)
+		if !k.backoff.Sleep(ctx, attempt) {
+			return false
+		}
+	}
+
+	if target == k.dlq.Topic {
+		metrics.BrokerDeadLettered.WithLabelValues(metrics.Topic(env.cacheTopic)).Inc()
+		k.log.Errorf("☠️ Message %s:%s moved to DLQ [%s] after %d attempts: %s", env.cacheTopic, m.Value, target, env.attempts, env.err)
+	} else {
+		metrics.BrokerRetried.WithLabelValues(metrics.Topic(env.cacheTopic)).Inc()
+		k.log.Warnf("⏳ Message %s:%s scheduled for retry round %d at %s", env.cacheTopic, m.Value, env.round, env.retryAt.Format(time.RFC3339))
+	}
+	return true
+}
+
+// consumeRetries retry topic 메시지를 예정 시각까지 기다린 뒤 원래 topic 메시지로 다시 처리
+func (k *kafkaBroker) consumeRetries(ctx context.Context, handler _interface.MessageHandler) {
+	commit := newOffsetCommitter(k.retryReader, k.readerCfg, k.log, nil)
+	defer commit.close()
+
+	for attempt := 1; ; {
+		m, err := k.retryReader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			k.log.Errorf("📉 Retry topic read failed (attempt %d): %v", attempt, err)
+			if !k.backoff.Sleep(ctx, attempt) {
+				return
+			}
+			attempt++
+			continue
+		}
+		attempt = 1
+		commit.track(m)
+
+		env := parseEnvelope(m)
+		if wait := time.Until(env.retryAt); wait > 0 {
+			t := time.NewTimer(wait)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return
+			case <-t.C:
+			}
+		}
+
+		msgCtx := extractContext(ctx, &m.Headers)
+		msg := _interface.Message{Topic: env.cacheTopic, Key: string(m.Value), Tenant: env.tenant, Timestamp: m.Time}
+		if err := handler(msgCtx, msg); err != nil {
+			if !k.deadLetter(ctx, m, env, 1, err) {
+				return
+			}
+		} else {
+			k.log.Infof("💚 Message %s recovered on retry round %d", msg, env.round)
+		}
+		commit.ack(m)
+	}
+}
+
+func (k *kafkaBroker) DeadLetters(ctx context.Context, limit int) ([]_interface.DeadLetter, error) {
+	if !k.dlq.Enabled {
+		return nil, ErrDeadLetterDisabled
+	}
+	partitions, err := k.partitions(ctx, k.dlq.Topic)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []_interface.DeadLetter
+	for _, p := range partitions {
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+		first, last, err := k.offsets(ctx, k.dlq.Topic, p)
+		if err != nil {
+			return nil, err
+		}
+		if first >= last {
+			continue
+		}
+
+		r := k.partitionReader(k.dlq.Topic, p)
+		if err := r.SetOffset(first); err != nil {
+			_ = r.Close()
+			return nil, err
+		}
+		for off := first; off < last && (limit <= 0 || len(out) < limit); off++ {
+			m, err := r.ReadMessage(ctx)
+			if err != nil {
+				_ = r.Close()
+				return nil, fmt.Errorf("read dlq partition %d: %w", p, err)
+			}
+			off = m.Offset
+			// tenant 로 한정된 요청에는 자기 tenant 의 메시지만 보여준다
+			if letter := toDeadLetter(m); visibleTo(ctx, letter.Tenant) {
+				out = append(out, letter)
+			}
+		}
+		_ = r.Close()
+	}
+	return out, nil
+}
+
+func (k *kafkaBroker) ReplayDeadLetter(ctx context.Context, partition int, offset int64) error {
+	if !k.dlq.Enabled {
+		return ErrDeadLetterDisabled
+	}
+	partitions, err := k.partitions(ctx, k.dlq.Topic)
+	if err != nil {
+		return err
+	}
+	known := false
+	for _, p := range partitions {
+		known = known || p == partition
+	}
+	if !known {
+		return fmt.Errorf("%w: %d/%d", ErrDeadLetterNotFound, partition, offset)
+	}
+	// 범위 밖 offset 은 ReadMessage 가 새 메시지를 기다리며 멈추므로 먼저 확인
+	first, last, err := k.offsets(ctx, k.dlq.Topic, partition)
+	if err != nil {
+		return err
+	}
+	if offset < first || offset >= last {
+		return fmt.Errorf("%w: %d/%d", ErrDeadLetterNotFound, partition, offset)
+	}
+
+	r := k.partitionReader(k.dlq.Topic, partition)
+	defer r.Close()
+
+	if err := r.SetOffset(offset); err != nil {
+		return err
+	}
+	m, err := r.ReadMessage(ctx)
+	if err != nil {
+		return fmt.Errorf("read dlq message %d/%d: %w", partition, offset, err)
+	}
+	env := parseEnvelope(m)
+	if m.Offset != offset || !visibleTo(ctx, env.tenant) {
+		return fmt.Errorf("%w: %d/%d", ErrDeadLetterNotFound, partition, offset)
+	}
+
+	logger.With(ctx, k.log).Infof("♻️ Replaying DLQ message %d/%d to [%s] topic=%s key=%s", partition, offset, env.topic, env.cacheTopic, m.Value)
+	if env.tenant != "" {
+		// 재발행 메시지에도 원래 tenant 를 실어 보낸다
+		ctx = tenant.WithContext(ctx, &tenant.Tenant{ID: env.tenant})
+	}
+	return k.publishTo(ctx, env.topic, env.cacheTopic, string(m.Value))
+}
+
+// visibleTo ctx 에 tenant 가 없으면(전역 관리자) 모든 메시지, 있으면 같은 tenant 의 메시지만 허용
+func visibleTo(ctx context.Context, tenantID string) bool {
+	t, ok := tenant.From(ctx)
+	return !ok || t.ID == tenantID
+}
+
+func toDeadLetter(m kafka.Message) _interface.DeadLetter {
+	env := parseEnvelope(m)
+	return _interface.DeadLetter{
+		Partition:  m.Partition,
+		Offset:     m.Offset,
+		Topic:      env.cacheTopic,
+		KafkaTopic: env.topic,
+		Key:        string(m.Value),
+		Tenant:     env.tenant,
+		Attempts:   env.attempts,
+		Error:      env.err,
+		FailedAt:   env.failedAt,
+	}
+}
+
+func (k *kafkaBroker) partitionReader(topic string, partition int) *kafka.Reader {
+	return kafka.NewReader(kafka.ReaderConfig{
+		Brokers:   k.brokers,
+		Topic:     topic,
+		Partition: partition,
+		MaxWait:   500 * time.Millisecond,
+	})
+}
+
+func (k *kafkaBroker) partitions(ctx context.Context, topic string) ([]int, error) {
+	conn, err := k.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	parts, err := conn.ReadPartitions(topic)
+	if err != nil {
+		return nil, fmt.Errorf("read partitions of %s: %w", topic, err)
+	}
+	ids := make([]int, 0, len(parts))
+	for _, p := range parts {
+		ids = append(ids, p.ID)
+	}
+	return ids, nil
+}
+
+func (k *kafkaBroker) offsets(ctx context.Context, topic string, partition int) (int64, int64, error) {
+	var lastErr error
+	for _, addr := range k.brokers {
+		conn, err := kafka.DialLeader(ctx, "tcp", addr, topic, partition)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		first, last, err := conn.ReadOffsets()
+		_ = conn.Close()
+		return first, last, err
+	}
+	return 0, 0, fmt.Errorf("dial leader of %s/%d: %w", topic, partition, lastErr)
+}
+
+// dial 설정된 broker 중 처음 연결되는 곳
+func (k *kafkaBroker) dial(ctx context.Context) (*kafka.Conn, error) {
+	lastErr := errors.New("no kafka brokers configured")
+	for _, addr := range k.brokers {
+		conn, err := kafka.DialContext(ctx, "tcp", addr)
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func withDeadLetterDefaults(cfg config.DeadLetterConfig, groupID string) config.DeadLetterConfig {
+	if cfg.RetryTopic == "" {
+		cfg.RetryTopic = groupID + "-retry"
+	}
+	if cfg.Topic == "" {
+		cfg.Topic = groupID + "-dlq"
+	}
+	if cfg.MaxRounds < 0 {
+		cfg.MaxRounds = 0
+	}
+	return cfg
+}
@@ -0,0 +1,105 @@
+package event_broker
+
+import (
+	"cache/config"
+	"cache/tenant"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestDefaultTopicIgnoresDeadLetterWriters(t *testing.T) {
+	k := &kafkaBroker{
+		topics: []string{"cache-a", "cache-b"},
+		writers: map[string]*kafka.Writer{
+			"cache-a": kafkaWriter([]string{"localhost:9092"}, "cache-a"),
+			"cache-b": kafkaWriter([]string{"localhost:9092"}, "cache-b"),
+		},
+		retryWriter: kafkaWriter([]string{"localhost:9092"}, "cache-retry"),
+		dlqWriter:   kafkaWriter([]string{"localhost:9092"}, "cache-dlq"),
+	}
+	for i := 0; i < 100; i++ {
+		if got := k.defaultTopic(); got != "cache-a" {
+			t.Fatalf("defaultTopic() = %q, want the first configured topic", got)
+		}
+	}
+	for _, name := range []string{"cache-retry", "cache-dlq"} {
+		if _, ok := k.writers[name]; ok {
+			t.Fatalf("%s writer must not be in the publish map", name)
+		}
+	}
+}
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	failed := time.UnixMilli(1_700_000_000_000)
+	in := envelope{
+		topic:      "cache-a",
+		cacheTopic: "users",
+		tenant:     "team-a",
+		attempts:   3,
+		round:      1,
+		err:        "redis down",
+		failedAt:   failed,
+		retryAt:    failed.Add(time.Second),
+	}
+	orig := kafka.Message{Key: []byte("k"), Value: []byte("1"), Headers: []kafka.Header{{Key: headerTenant, Value: []byte("team-a")}}}
+
+	out := parseEnvelope(in.message(orig))
+	if out.topic != in.topic || out.cacheTopic != in.cacheTopic || out.tenant != in.tenant || out.attempts != in.attempts || out.round != in.round || out.err != in.err {
+		t.Fatalf("parseEnvelope() = %+v, want %+v", out, in)
+	}
+	if !out.failedAt.Equal(in.failedAt) || !out.retryAt.Equal(in.retryAt) {
+		t.Fatalf("times = %v/%v, want %v/%v", out.failedAt, out.retryAt, in.failedAt, in.retryAt)
+	}
+}
+
+func TestWithDeadLetterDefaults(t *testing.T) {
+	cfg := withDeadLetterDefaults(config.DeadLetterConfig{MaxRounds: -1}, "group")
+	if cfg.RetryTopic != "group-retry" || cfg.Topic != "group-dlq" || cfg.MaxRounds != 0 {
+		t.Fatalf("withDeadLetterDefaults() = %+v", cfg)
+	}
+}
+
+func TestToDeadLetterKeepsCacheTopic(t *testing.T) {
+	env := envelope{topic: "cache-a", cacheTopic: "users", attempts: 1}
+	m := env.message(kafka.Message{Value: []byte("42")})
+	m.Topic, m.Partition, m.Offset = "cache-dlq", 2, 7
+
+	letter := toDeadLetter(m)
+	if letter.Topic != "users" || letter.KafkaTopic != "cache-a" || letter.Key != "42" {
+		t.Fatalf("toDeadLetter() = %+v", letter)
+	}
+}
+
+func TestParseEnvelopeOfFirstFailure(t *testing.T) {
+	// 처음 실패한 메시지에는 envelope 헤더가 없다
+	env := parseEnvelope(kafka.Message{Topic: "cache-a", Value: []byte("42")})
+	if env.topic != "cache-a" || env.cacheTopic != "cache-a" || env.attempts != 0 || env.round != 0 {
+		t.Fatalf("parseEnvelope() = %+v", env)
+	}
+}
+
+func TestVisibleTo(t *testing.T) {
+	admin := context.Background()
+	scoped := tenant.WithContext(admin, &tenant.Tenant{ID: "team-a"})
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		tenant string
+		want   bool
+	}{
+		{"admin sees untenanted", admin, "", true},
+		{"admin sees any tenant", admin, "team-b", true},
+		{"tenant sees own", scoped, "team-a", true},
+		{"tenant does not see others", scoped, "team-b", false},
+		{"tenant does not see untenanted", scoped, "", false},
+	}
+	for _, tt := range tests {
+		if got := visibleTo(tt.ctx, tt.tenant); got != tt.want {
+			t.Errorf("%s: visibleTo = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
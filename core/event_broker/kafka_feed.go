@@ -0,0 +1,78 @@
+package event_broker
+
+import (
+	"cache/infrautil"
+	_interface "cache/interface"
+	"context"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Feed consumer group 없이 모든 partition 을 끝에서부터 읽어 handler 로 전달한다.
+// 모든 노드가 group 을 공유하는 Subscribe 는 partition 을 나눠 받으므로, SSE/WebSocket 처럼
+// 노드마다 전체 invalidation 을 보여줘야 하는 곳은 이 feed 를 쓴다. offset 은 commit 하지 않는다
+func (k *kafkaBroker) Feed(ctx context.Context, handler _interface.MessageHandler) error {
+	ctx, cancel := context.WithCancel(ctx)
+	k.lock.Lock()
+	k.feedCtx = ctx
+	k.feedHandler = handler
+	k.stopFeed = cancel
+	topics := append([]string(nil), k.topics...)
+	k.lock.Unlock()
+
+	for _, t := range topics {
+		k.startFeed(ctx, handler, t)
+	}
+	return nil
+}
+
+// startFeed topic 의 partition 마다 reader 를 띄운다. partition 조회가 실패하면 backoff 후 다시 시도
+func (k *kafkaBroker) startFeed(ctx context.Context, handler _interface.MessageHandler, topic string) {
+	k.loopWg.Add(1)
+	go func() {
+		defer k.loopWg.Done()
+		for attempt := 1; ; attempt++ {
+			partitions, err := k.partitions(ctx, topic)
+			if err == nil {
+				for _, p := range partitions {
+					k.loopWg.Add(1)
+					go func(p int) {
+						defer k.loopWg.Done()
+						k.feedPartition(ctx, handler, topic, p)
+					}(p)
+				}
+				return
+			}
+			k.log.Warnf("⚠️ Event feed cannot list partitions of [%s] (attempt %d): %v", topic, attempt, err)
+			if !k.backoff.Sleep(ctx, attempt) {
+				return
+			}
+		}
+	}()
+}
+
+func (k *kafkaBroker) feedPartition(ctx context.Context, handler _interface.MessageHandler, topic string, partition int) {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     k.brokers,
+		Topic:       topic,
+		Partition:   partition,
+		StartOffset: kafka.LastOffset,
+		MaxWait:     time.Duration(k.readerCfg.MaxWaitMs) * time.Millisecond,
+		ErrorLogger: kafka.LoggerFunc(k.log.Debugf),
+	})
+	defer reader.Close()
+
+	log := k.log.With("feed", topic, "partition", partition)
+	infrautil.RunMessageLoop(ctx, log, k.backoff, func() (delivery, error) {
+		m, err := reader.ReadMessage(ctx)
+		if err != nil {
+			return delivery{}, err
+		}
+		return newDelivery(ctx, m), nil
+	}, func(d delivery) {
+		if err := handler(d.ctx, d.msg); err != nil {
+			log.Warnf("❗ Event feed handler failed for %s: %v", d.msg, err)
+		}
+	}, func(int, error) {})
+}
@@ -0,0 +1,74 @@
+package event_broker
+
+import (
+	"cache/requestid"
+	"cache/tenant"
+	"context"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	// headerTenant 발행한 요청의 tenant ID. 수신 측이 같은 tenant 의 key 를 무효화하는 데 쓴다
+	headerTenant = "cache-tenant"
+	// headerCacheTopic 무효화할 캐시 topic. Kafka topic 은 tenant 설정에 따라 달라지므로 따로 싣는다
+	headerCacheTopic = "cache-topic"
+	// headerRequestID 발행한 HTTP/gRPC 요청의 request ID. 수신 측 로그를 요청과 묶는 데 쓴다
+	headerRequestID = "cache-request-id"
+)
+
+// injectHeaders 발행할 메시지에 trace context, tenant, request ID 를 싣는다
+func injectHeaders(ctx context.Context, headers *[]kafka.Header) {
+	c := kafkaHeaderCarrier{headers: headers}
+	otel.GetTextMapPropagator().Inject(ctx, c)
+	if id := tenant.ID(ctx); id != "" {
+		c.Set(headerTenant, id)
+	}
+	if id := requestid.From(ctx); id != "" {
+		c.Set(headerRequestID, id)
+	}
+}
+
+// extractContext 수신한 메시지 헤더에서 trace context 와 request ID 를 복원한다.
+// 종료 중에도 처리 중인 invalidation 은 끝까지 수행하도록 취소는 이어받지 않는다
+func extractContext(ctx context.Context, headers *[]kafka.Header) context.Context {
+	c := kafkaHeaderCarrier{headers: headers}
+	ctx = otel.GetTextMapPropagator().Extract(context.WithoutCancel(ctx), c)
+	if id := c.Get(headerRequestID); id != "" {
+		ctx = requestid.WithContext(ctx, id)
+	}
+	return ctx
+}
+
+// kafkaHeaderCarrier kafka 메시지 헤더를 OpenTelemetry TextMapCarrier 로 사용
+type kafkaHeaderCarrier struct {
+	headers *[]kafka.Header
+}
+
+func (c kafkaHeaderCarrier) Get(key string) string {
+	for _, h := range *c.headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func (c kafkaHeaderCarrier) Set(key string, value string) {
+	for i, h := range *c.headers {
+		if h.Key == key {
+			(*c.headers)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c.headers = append(*c.headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+func (c kafkaHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(*c.headers))
+	for _, h := range *c.headers {
+		keys = append(keys, h.Key)
+	}
+	return keys
+}
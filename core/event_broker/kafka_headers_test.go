@@ -0,0 +1,110 @@
+package event_broker
+
+import (
+	"cache/config"
+	_interface "cache/interface"
+	"cache/tracing"
+	"context"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// useRecorder 테스트 동안 span 을 기록하는 provider 로 교체
+func useRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	if _, err := tracing.Init(config.TracingConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return recorder
+}
+
+func TestTraceContextSurvivesKafkaHeaders(t *testing.T) {
+	useRecorder(t)
+	ctx, span := tracing.Tracer().Start(context.Background(), "publish")
+	defer span.End()
+
+	var headers []kafka.Header
+	injectHeaders(ctx, &headers)
+	got := trace.SpanContextFromContext(extractContext(context.Background(), &headers))
+
+	if !got.IsRemote() || got.TraceID() != span.SpanContext().TraceID() || got.SpanID() != span.SpanContext().SpanID() {
+		t.Fatalf("extracted %v, want remote parent %v", got, span.SpanContext())
+	}
+}
+
+// headerBroker 발행한 메시지 헤더를 그대로 수신 측에 넘기는 broker
+type headerBroker struct {
+	handler _interface.MessageHandler
+}
+
+func (b *headerBroker) Ping(context.Context) error                { return nil }
+func (b *headerBroker) ConsumerStatus() _interface.ConsumerStatus { return _interface.ConsumerStatus{} }
+func (b *headerBroker) Close() error                              { return nil }
+
+func (b *headerBroker) Subscribe(_ context.Context, h _interface.MessageHandler) error {
+	b.handler = h
+	return nil
+}
+
+// Destination 모든 캐시 topic 을 하나의 broker topic 으로 보낸다
+func (b *headerBroker) Destination(context.Context, string) string { return "cache-events" }
+
+func (b *headerBroker) Publish(ctx context.Context, topic string, key string) error {
+	var headers []kafka.Header
+	injectHeaders(ctx, &headers)
+	msg := _interface.Message{Topic: topic, Key: key, Destination: b.Destination(ctx, topic)}
+	return b.handler(extractContext(context.Background(), &headers), msg)
+}
+
+func TestConsumerSpanContinuesPublishTrace(t *testing.T) {
+	recorder := useRecorder(t)
+	broker := NewTracingBroker(&headerBroker{}, "kafka")
+	if err := broker.Subscribe(context.Background(), func(context.Context, _interface.Message) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := broker.Publish(context.Background(), "users", "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("spans = %d, want 2", len(spans))
+	}
+	var publish, process sdktrace.ReadOnlySpan
+	for _, s := range spans {
+		switch s.SpanKind() {
+		case trace.SpanKindProducer:
+			publish = s
+		case trace.SpanKindConsumer:
+			process = s
+		}
+	}
+	if publish == nil || process == nil {
+		t.Fatalf("missing producer or consumer span: %v", spans)
+	}
+	if process.Parent().SpanID() != publish.SpanContext().SpanID() || process.SpanContext().TraceID() != publish.SpanContext().TraceID() {
+		t.Fatal("consumer span is not a child of the publish span")
+	}
+
+	// destination 은 broker topic, 캐시 topic 은 별도 속성
+	for _, s := range []sdktrace.ReadOnlySpan{publish, process} {
+		attrs := attribute.NewSet(s.Attributes()...)
+		if v, _ := attrs.Value("messaging.destination.name"); v.AsString() != "cache-events" {
+			t.Fatalf("%s: messaging.destination.name = %q, want cache-events", s.Name(), v.AsString())
+		}
+		if v, _ := attrs.Value("cache.topic"); v.AsString() != "users" {
+			t.Fatalf("%s: cache.topic = %q, want users", s.Name(), v.AsString())
+		}
+	}
+}
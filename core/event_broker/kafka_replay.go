@@ -0,0 +1,201 @@
+package event_broker
+
+import (
+	_interface "cache/interface"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	replayNone       = "none"
+	replayTimestamp  = "timestamp"
+	replayOffset     = "offset"
+	replayCheckpoint = "checkpoint"
+)
+
+// checkpointStore commit 한 offset 을 노드 로컬 파일에 기록. 모든 노드가 group 을 공유하므로
+// group offset 과 별개로 이 노드가 어디까지 적용했는지 보관한다
+type checkpointStore struct {
+	path    string
+	mu      sync.Mutex
+	offsets map[string]map[string]int64 // topic -> partition -> 다음에 읽을 offset
+}
+
+func loadCheckpoint(path string) (*checkpointStore, error) {
+	s := &checkpointStore{path: path, offsets: make(map[string]map[string]int64)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("read checkpoint %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, &s.offsets); err != nil {
+		return s, fmt.Errorf("parse checkpoint %s: %w", path, err)
+	}
+	return s, nil
+}
+
+func (s *checkpointStore) offset(topic string, partition int) (int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	off, ok := s.offsets[topic][strconv.Itoa(partition)]
+	return off, ok
+}
+
+// update commit 된 메시지 기준으로 기록. 임시 파일에 쓴 뒤 rename 해 부분 기록을 막는다
+func (s *checkpointStore) update(msgs []kafka.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, m := range msgs {
+		parts, ok := s.offsets[m.Topic]
+		if !ok {
+			parts = make(map[string]int64)
+			s.offsets[m.Topic] = parts
+		}
+		parts[strconv.Itoa(m.Partition)] = m.Offset + 1
+	}
+
+	data, err := json.Marshal(s.offsets)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// replay group 소비 전에 설정된 시작 지점부터 현재 끝까지의 메시지를 다시 적용한다.
+// 진행 중에는 ConsumerStatus.Replaying 이 true 라 readiness 가 down 으로 유지된다
+func (k *kafkaBroker) replay(ctx context.Context, handler _interface.MessageHandler) {
+	defer k.consumer.setReplaying(false)
+	if !k.replayEnabled() {
+		return
+	}
+
+	cfg := k.readerCfg.Replay
+
+	var since time.Time
+	if cfg.Mode == replayTimestamp {
+		var err error
+		if since, err = parseSince(cfg.Since); err != nil {
+			k.log.Errorf("❌ Invalid replay.since %q, skipping replay: %v", cfg.Since, err)
+			return
+		}
+	}
+	if cfg.Mode == replayCheckpoint && k.checkpoint == nil {
+		k.log.Warnf("⚠️ Replay mode checkpoint requires replay.checkpoint_file, skipping replay")
+		return
+	}
+
+	started := time.Now()
+	total := 0
+	for _, topic := range k.topics {
+		partitions, err := k.partitions(ctx, topic)
+		if err != nil {
+			k.log.Warnf("⚠️ Replay skipped for topic [%s]: %v", topic, err)
+			continue
+		}
+		for _, p := range partitions {
+			n, err := k.replayPartition(ctx, handler, topic, p, since)
+			total += n
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				k.log.Warnf("⚠️ Replay of [%s/%d] stopped after %d messages: %v", topic, p, n, err)
+			}
+		}
+	}
+	k.log.Infof("⏪ Replayed %d invalidations (mode=%s) in %s", total, cfg.Mode, time.Since(started))
+}
+
+func (k *kafkaBroker) replayEnabled() bool {
+	mode := k.readerCfg.Replay.Mode
+	return mode != "" && mode != replayNone
+}
+
+func (k *kafkaBroker) replayPartition(ctx context.Context, handler _interface.MessageHandler, topic string, partition int, since time.Time) (int, error) {
+	first, last, err := k.offsets(ctx, topic, partition)
+	if err != nil {
+		return 0, err
+	}
+
+	r := k.partitionReader(topic, partition)
+	defer r.Close()
+
+	start := first
+	switch k.readerCfg.Replay.Mode {
+	case replayTimestamp:
+		if err := r.SetOffsetAt(ctx, since); err != nil {
+			return 0, err
+		}
+		start = r.Offset()
+	case replayOffset:
+		if off := k.readerCfg.Replay.Offset; off >= 0 {
+			start = off
+		} else if off == kafka.LastOffset {
+			start = last
+		}
+	case replayCheckpoint:
+		off, ok := k.checkpoint.offset(topic, partition)
+		if !ok {
+			return 0, nil
+		}
+		start = off
+	default:
+		return 0, fmt.Errorf("unsupported replay mode: %s", k.readerCfg.Replay.Mode)
+	}
+	// retention 으로 이미 지워진 구간은 남아 있는 처음부터
+	start = max(start, first)
+	if start >= last {
+		return 0, nil
+	}
+	if err := r.SetOffset(start); err != nil {
+		return 0, err
+	}
+
+	k.log.Infof("⏪ Replaying [%s/%d] offsets %d..%d", topic, partition, start, last-1)
+	n := 0
+	for {
+		m, err := r.ReadMessage(ctx)
+		if err != nil {
+			return n, err
+		}
+		d := newDelivery(ctx, m)
+		if _, err := k.process(ctx, handler, d); err != nil && ctx.Err() == nil {
+			k.log.Errorf("❌ Replay of message %s [offset=%d] failed: %v", d.msg, m.Offset, err)
+		}
+		n++
+		if m.Offset >= last-1 {
+			return n, nil
+		}
+	}
+}
+
+// parseSince RFC3339 시각 또는 현재 기준 기간
+func parseSince(raw string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Now().Add(-d), nil
+}
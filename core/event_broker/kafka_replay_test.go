@@ -0,0 +1,66 @@
+package event_broker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestCheckpointRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "checkpoint.json")
+	s, err := loadCheckpoint(path)
+	if err != nil {
+		t.Fatalf("missing file: %v", err)
+	}
+	if _, ok := s.offset("cache-a", 0); ok {
+		t.Fatal("empty checkpoint has an offset")
+	}
+
+	if err := s.update([]kafka.Message{{Topic: "cache-a", Partition: 0, Offset: 41}, {Topic: "cache-a", Partition: 2, Offset: 9}}); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := loadCheckpoint(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 다음에 읽을 offset 을 기록한다
+	if off, ok := loaded.offset("cache-a", 0); !ok || off != 42 {
+		t.Fatalf("offset(cache-a, 0) = %d, %t, want 42", off, ok)
+	}
+	if off, ok := loaded.offset("cache-a", 2); !ok || off != 10 {
+		t.Fatalf("offset(cache-a, 2) = %d, %t, want 10", off, ok)
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Fatal("temporary checkpoint file left behind")
+	}
+}
+
+func TestLoadCheckpointRejectsCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadCheckpoint(path); err == nil {
+		t.Fatal("expected error for corrupt checkpoint")
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	at, err := parseSince("2026-01-02T03:04:05Z")
+	if err != nil || !at.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Fatalf("RFC3339 = %v, %v", at, err)
+	}
+
+	at, err = parseSince("15m")
+	if ago := time.Since(at); err != nil || ago < 15*time.Minute || ago > 16*time.Minute {
+		t.Fatalf("duration = %v, %v", at, err)
+	}
+
+	if _, err := parseSince("yesterday"); err == nil {
+		t.Fatal("expected error for invalid since")
+	}
+}
@@ -0,0 +1,64 @@
+package event_broker
+
+import (
+	_interface "cache/interface"
+	"cache/metrics"
+	"context"
+	"time"
+)
+
+type metricsBroker struct {
+	next _interface.IEventBroker
+}
+
+// NewMetricsBroker publish/consume 횟수와 invalidation 지연을 기록하는 데코레이터
+func NewMetricsBroker(next _interface.IEventBroker) _interface.IEventBroker {
+	if lr, ok := next.(interface{ ConsumerLag() int64 }); ok {
+		metrics.SetLagSource(lr.ConsumerLag)
+	}
+	return &metricsBroker{next: next}
+}
+
+func (m *metricsBroker) Publish(ctx context.Context, topic string, key string) error {
+	err := m.next.Publish(ctx, topic, key)
+	m.observePublish(topic, err)
+	return err
+}
+
+func (m *metricsBroker) observePublish(topic string, err error) {
+	if err != nil {
+		metrics.BrokerPublishErrors.WithLabelValues(metrics.Topic(topic)).Inc()
+		return
+	}
+	metrics.BrokerPublished.WithLabelValues(metrics.Topic(topic)).Inc()
+}
+
+func (m *metricsBroker) Subscribe(ctx context.Context, handler _interface.MessageHandler) error {
+	return m.next.Subscribe(ctx, func(ctx context.Context, msg _interface.Message) error {
+		metrics.BrokerConsumed.WithLabelValues(metrics.Topic(msg.Topic)).Inc()
+		if err := handler(ctx, msg); err != nil {
+			metrics.BrokerHandlerErrors.WithLabelValues(metrics.Topic(msg.Topic)).Inc()
+			return err
+		}
+		if !msg.Timestamp.IsZero() {
+			metrics.InvalidationDelay.Observe(time.Since(msg.Timestamp).Seconds())
+		}
+		return nil
+	})
+}
+
+func (m *metricsBroker) Ping(ctx context.Context) error {
+	return m.next.Ping(ctx)
+}
+
+func (m *metricsBroker) ConsumerStatus() _interface.ConsumerStatus {
+	return m.next.ConsumerStatus()
+}
+
+func (m *metricsBroker) Close() error {
+	return m.next.Close()
+}
+
+func (m *metricsBroker) Unwrap() _interface.IEventBroker {
+	return m.next
+}
@@ -0,0 +1,83 @@
+package event_broker
+
+import (
+	_interface "cache/interface"
+	"cache/tracing"
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type tracingBroker struct {
+	next   _interface.IEventBroker
+	system string
+}
+
+// NewTracingBroker publish/consume span 을 남기는 데코레이터.
+// trace context 의 메시지 전달은 각 broker 구현이 담당한다.
+func NewTracingBroker(next _interface.IEventBroker, system string) _interface.IEventBroker {
+	return &tracingBroker{next: next, system: system}
+}
+
+// destinationResolver 캐시 topic 을 실제로 발행할 broker topic 으로 바꿔 주는 broker
+type destinationResolver interface {
+	Destination(ctx context.Context, topic string) string
+}
+
+func (t *tracingBroker) startPublish(ctx context.Context, topic string, key string) (context.Context, trace.Span) {
+	destination := topic
+	if r, ok := find[destinationResolver](t.next); ok {
+		destination = r.Destination(ctx, topic)
+	}
+	return tracing.Tracer().Start(ctx, destination+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", t.system),
+			attribute.String("messaging.destination.name", destination),
+			attribute.String("cache.topic", topic),
+			attribute.String("cache.key", key),
+		))
+}
+
+func (t *tracingBroker) Publish(ctx context.Context, topic string, key string) (err error) {
+	ctx, span := t.startPublish(ctx, topic, key)
+	defer func() { tracing.End(span, err) }()
+	return t.next.Publish(ctx, topic, key)
+}
+
+func (t *tracingBroker) Subscribe(ctx context.Context, handler _interface.MessageHandler) error {
+	return t.next.Subscribe(ctx, func(ctx context.Context, msg _interface.Message) error {
+		destination := msg.Destination
+		if destination == "" {
+			destination = msg.Topic
+		}
+		ctx, span := tracing.Tracer().Start(ctx, destination+" process",
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				attribute.String("messaging.system", t.system),
+				attribute.String("messaging.destination.name", destination),
+				attribute.String("cache.topic", msg.Topic),
+				attribute.String("cache.key", msg.Key),
+			))
+		err := handler(ctx, msg)
+		tracing.End(span, err)
+		return err
+	})
+}
+
+func (t *tracingBroker) Ping(ctx context.Context) error {
+	return t.next.Ping(ctx)
+}
+
+func (t *tracingBroker) ConsumerStatus() _interface.ConsumerStatus {
+	return t.next.ConsumerStatus()
+}
+
+func (t *tracingBroker) Close() error {
+	return t.next.Close()
+}
+
+func (t *tracingBroker) Unwrap() _interface.IEventBroker {
+	return t.next
+}
@@ -0,0 +1,37 @@
+package event_broker
+
+import (
+	_interface "cache/interface"
+)
+
+// AsDeadLetterQueue 데코레이터를 벗겨 DLQ 를 지원하는 broker 를 찾는다
+func AsDeadLetterQueue(b _interface.IEventBroker) (_interface.IDeadLetterQueue, bool) {
+	return find[_interface.IDeadLetterQueue](b)
+}
+
+// AsTopicManager 데코레이터를 벗겨 실행 중 topic 추가를 지원하는 broker 를 찾는다
+func AsTopicManager(b _interface.IEventBroker) (_interface.ITopicManager, bool) {
+	return find[_interface.ITopicManager](b)
+}
+
+// AsEventFeed 데코레이터를 벗겨 노드 단위 이벤트 feed 를 지원하는 broker 를 찾는다
+func AsEventFeed(b _interface.IEventBroker) (_interface.IEventFeed, bool) {
+	return find[_interface.IEventFeed](b)
+}
+
+func find[T any](b _interface.IEventBroker) (T, bool) {
+	for b != nil {
+		if t, ok := b.(T); ok {
+			return t, true
+		}
+		u, ok := b.(interface {
+			Unwrap() _interface.IEventBroker
+		})
+		if !ok {
+			break
+		}
+		b = u.Unwrap()
+	}
+	var zero T
+	return zero, false
+}
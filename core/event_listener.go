@@ -1,25 +1,239 @@
 package core
 
 import (
+	"cache/core/event_broker"
 	"cache/interface"
+	"cache/tenant"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
+// eventHistorySize Last-Event-ID 재개를 위해 보관하는 최근 이벤트 수
+const eventHistorySize = 1024
+
+// InvalidationEvent 브로커로부터 수신해 처리한 무효화 이벤트
+type InvalidationEvent struct {
+	ID     uint64 // 이 listener 안에서의 순번. 외부에 노출할 때는 EventID 로 epoch 를 붙인다
+	Tenant string
+	Topic  string
+	Key    string
+	Time   time.Time
+}
+
 type EventListener struct {
-	broker _interface.IEventBroker
-	cache  *CacheService
+	broker  _interface.IEventBroker
+	cache   *CacheService
+	tenants *tenant.Resolver
+
+	// epoch 프로세스마다 새로 만드는 값. 다른 노드나 재시작 전의 이벤트 ID 로는 재개하지 않는다
+	epoch string
+
+	mu       sync.Mutex
+	running  bool
+	lastErr  error
+	seq      uint64 // feed 로 관찰한 이벤트 순번
+	history  []InvalidationEvent
+	watchers map[chan InvalidationEvent]struct{}
+
+	processed   uint64 // 이 노드가 invalidate 한 메시지 수
+	processedAt time.Time
 }
 
 // NewEventListener 생성자 함수: 의존성 주입
 func NewEventListener(b _interface.IEventBroker, c *CacheService) *EventListener {
 	return &EventListener{
-		broker: b,
-		cache:  c,
+		broker:   b,
+		cache:    c,
+		epoch:    newEpoch(),
+		history:  make([]InvalidationEvent, 0, eventHistorySize),
+		watchers: make(map[chan InvalidationEvent]struct{}),
 	}
 }
 
-// Start 브로커로부터 메시지를 수신해 invalidate 처리
-func (e *EventListener) Start() {
-	_ = e.broker.Subscribe(func(topic string, key string) {
-		_ = e.cache.Invalidate(topic, key)
+// SetTenants 메시지에 실린 tenant ID 를 복원할 Resolver. Start 전에 호출해야 한다
+func (e *EventListener) SetTenants(r *tenant.Resolver) {
+	e.tenants = r
+}
+
+// ListenerStatus health check 용 listener 상태
+type ListenerStatus struct {
+	Running      bool
+	Error        string
+	Processed    uint64
+	LastEventAt  time.Time
+	ReadFailures int
+	ReadError    string
+	Replaying    bool
+}
+
+// Start 브로커로부터 메시지를 수신해 invalidate 처리. ctx 가 취소되면 수신을 멈춘다.
+// broker 가 노드 단위 feed 를 지원하면 Watch 구독자에게는 그 feed 의 이벤트를 보낸다.
+// group 으로 나눠 받는 Subscribe 만으로는 이 노드에 배정된 partition 의 이벤트만 보이기 때문이다
+func (e *EventListener) Start(ctx context.Context) {
+	feed, hasFeed := event_broker.AsEventFeed(e.broker)
+	err := e.broker.Subscribe(ctx, func(ctx context.Context, msg _interface.Message) error {
+		if msg.Tenant != "" {
+			t, ok := e.tenants.Lookup(msg.Tenant)
+			if !ok {
+				// 설정이 노드마다 다를 수 있으므로 재시도/DLQ 로 넘긴다
+				return fmt.Errorf("invalidate %s: %w %q", msg, tenant.ErrUnknownTenant, msg.Tenant)
+			}
+			ctx = tenant.WithContext(ctx, t)
+		}
+		if err := e.cache.Invalidate(ctx, msg.Topic, msg.Key); err != nil {
+			return fmt.Errorf("invalidate %s: %w", msg, err)
+		}
+		e.markProcessed()
+		if !hasFeed {
+			e.notify(msg.Tenant, msg.Topic, msg.Key)
+		}
+		return nil
 	})
+	if err == nil && hasFeed {
+		err = feed.Feed(ctx, func(ctx context.Context, msg _interface.Message) error {
+			e.notify(msg.Tenant, msg.Topic, msg.Key)
+			return nil
+		})
+	}
+
+	e.mu.Lock()
+	e.running = err == nil
+	e.lastErr = err
+	e.mu.Unlock()
+
+	if err == nil {
+		go func() {
+			<-ctx.Done()
+			e.mu.Lock()
+			e.running = false
+			e.mu.Unlock()
+		}()
+	}
+}
+
+// Status 현재 listener 상태
+func (e *EventListener) Status() ListenerStatus {
+	consumer := e.broker.ConsumerStatus()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	status := ListenerStatus{
+		Running:      e.running,
+		Processed:    e.processed,
+		LastEventAt:  e.processedAt,
+		ReadFailures: consumer.ReadFailures,
+		ReadError:    consumer.ReadError,
+		Replaying:    consumer.Replaying,
+	}
+	if e.lastErr != nil {
+		status.Error = e.lastErr.Error()
+	}
+	return status
+}
+
+func (e *EventListener) markProcessed() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.processed++
+	e.processedAt = time.Now()
+}
+
+// Watch 지금부터 처리되는 invalidation 이벤트 구독. 반환된 함수로 구독 해제
+func (e *EventListener) Watch(buffer int) (<-chan InvalidationEvent, func()) {
+	_, _, ch, cancel := e.WatchFrom("", buffer)
+	return ch, cancel
+}
+
+// EventID 클라이언트에 노출하는 이벤트 ID. "<epoch>-<순번>" 형식
+func (e *EventListener) EventID(id uint64) string {
+	return e.epoch + "-" + strconv.FormatUint(id, 10)
+}
+
+// WatchFrom lastEventID 이후의 보관된 이벤트와 함께 구독. lastEventID 가 비어 있으면 지금부터.
+// complete 가 false 면 재개할 수 없다: 보관 범위를 벗어났거나, 다른 노드 또는 재시작 전의 ID 다.
+// 버퍼가 가득 찬 느린 구독자는 채널이 닫히며 구독이 해제된다.
+func (e *EventListener) WatchFrom(lastEventID string, buffer int) (replay []InvalidationEvent, complete bool, events <-chan InvalidationEvent, cancel func()) {
+	ch := make(chan InvalidationEvent, buffer)
+
+	e.mu.Lock()
+	complete = true
+	if lastEventID != "" {
+		if lastID, ok := e.parseEventID(lastEventID); ok {
+			replay, complete = e.since(lastID)
+		} else {
+			replay, complete = append([]InvalidationEvent(nil), e.history...), false
+		}
+	}
+	e.watchers[ch] = struct{}{}
+	e.mu.Unlock()
+
+	return replay, complete, ch, func() {
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		if _, ok := e.watchers[ch]; ok {
+			delete(e.watchers, ch)
+			close(ch)
+		}
+	}
+}
+
+// parseEventID 이 listener 가 발급한 ID 면 순번을 반환
+func (e *EventListener) parseEventID(raw string) (uint64, bool) {
+	epoch, seq, ok := strings.Cut(raw, "-")
+	if !ok || epoch != e.epoch {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(seq, 10, 64)
+	if err != nil || id > e.seq {
+		return 0, false
+	}
+	return id, true
+}
+
+// since lastID 이후 이벤트. e.mu 를 잡은 상태에서 호출
+func (e *EventListener) since(lastID uint64) ([]InvalidationEvent, bool) {
+	if len(e.history) == 0 || lastID+1 < e.history[0].ID {
+		return append([]InvalidationEvent(nil), e.history...), lastID == e.seq
+	}
+	start := int(lastID + 1 - e.history[0].ID)
+	return append([]InvalidationEvent(nil), e.history[start:]...), true
+}
+
+func (e *EventListener) notify(tenantID string, topic string, key string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.seq++
+	ev := InvalidationEvent{ID: e.seq, Tenant: tenantID, Topic: topic, Key: key, Time: time.Now()}
+	if len(e.history) == eventHistorySize {
+		copy(e.history, e.history[1:])
+		e.history[len(e.history)-1] = ev
+	} else {
+		e.history = append(e.history, ev)
+	}
+
+	for ch := range e.watchers {
+		select {
+		case ch <- ev:
+		default:
+			// 느린 구독자가 listener 를 막지 않도록 구독 해제
+			delete(e.watchers, ch)
+			close(ch)
+		}
+	}
+}
+
+func newEpoch() string {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(b[:])
 }
@@ -0,0 +1,152 @@
+package core
+
+import (
+	"cache/config"
+	"cache/core/cache_adapter"
+	"cache/core/strategy"
+	_interface "cache/interface"
+	"context"
+	"testing"
+)
+
+func TestWatchFromResumesOnlyWithOwnEventIDs(t *testing.T) {
+	l := NewEventListener(nil, nil)
+	for i := 0; i < 3; i++ {
+		l.notify("", "users", "k")
+	}
+	other := NewEventListener(nil, nil)
+
+	tests := []struct {
+		name         string
+		lastEventID  string
+		wantComplete bool
+		wantReplay   int
+	}{
+		{"fresh subscription", "", true, 0},
+		{"resume after first event", l.EventID(1), true, 2},
+		{"up to date", l.EventID(3), true, 0},
+		{"id from another node", other.EventID(1), false, 3},
+		{"id ahead of this process", l.EventID(10), false, 3},
+		{"legacy numeric id", "2", false, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replay, complete, _, cancel := l.WatchFrom(tt.lastEventID, 1)
+			defer cancel()
+			if complete != tt.wantComplete || len(replay) != tt.wantReplay {
+				t.Fatalf("WatchFrom(%q) = %d events, complete=%t; want %d, %t", tt.lastEventID, len(replay), complete, tt.wantReplay, tt.wantComplete)
+			}
+		})
+	}
+}
+
+func TestWatchFromOutsideHistoryIsIncomplete(t *testing.T) {
+	l := NewEventListener(nil, nil)
+	for i := 0; i < eventHistorySize+10; i++ {
+		l.notify("", "users", "k")
+	}
+	if _, complete, _, cancel := l.WatchFrom(l.EventID(1), 1); complete {
+		cancel()
+		t.Fatal("resuming from an evicted event must not be complete")
+	} else {
+		cancel()
+	}
+}
+
+func TestSlowWatcherIsDropped(t *testing.T) {
+	l := NewEventListener(nil, nil)
+	ch, cancel := l.Watch(1)
+	defer cancel()
+
+	l.notify("", "users", "a")
+	l.notify("", "users", "b")
+
+	if ev := <-ch; ev.Key != "a" {
+		t.Fatalf("first event = %+v", ev)
+	}
+	if _, ok := <-ch; ok {
+		t.Fatal("channel should be closed after the buffer overflowed")
+	}
+}
+
+// feedBroker Subscribe 와 Feed 로 받은 handler 를 보관해 테스트에서 직접 호출한다
+type feedBroker struct {
+	_interface.IEventBroker
+	subscribe _interface.MessageHandler
+	feed      _interface.MessageHandler
+}
+
+func (b *feedBroker) Subscribe(_ context.Context, h _interface.MessageHandler) error {
+	b.subscribe = h
+	return nil
+}
+
+func (b *feedBroker) Feed(_ context.Context, h _interface.MessageHandler) error {
+	b.feed = h
+	return nil
+}
+
+func (b *feedBroker) ConsumerStatus() _interface.ConsumerStatus {
+	return _interface.ConsumerStatus{}
+}
+
+func newTestService() *CacheService {
+	return NewCacheService(
+		cache_adapter.NewMemoryAdapter(config.MemoryConfig{}),
+		strategy.NewVersionedKeyStrategy(config.InvalidationConfig{}),
+	)
+}
+
+func TestWatchersSeeFeedNotGroupMessages(t *testing.T) {
+	broker := &feedBroker{}
+	l := NewEventListener(broker, newTestService())
+	l.Start(context.Background())
+	ch, cancel := l.Watch(4)
+	defer cancel()
+
+	ctx := context.Background()
+	// 이 노드가 처리한 group 메시지는 feed 로도 들어오므로 두 번 알리지 않는다
+	if err := broker.subscribe(ctx, _interface.Message{Topic: "users", Key: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	// 다른 노드의 partition 에 속한 메시지도 feed 로 보인다
+	_ = broker.feed(ctx, _interface.Message{Topic: "users", Key: "1"})
+	_ = broker.feed(ctx, _interface.Message{Topic: "users", Key: "2"})
+
+	for _, want := range []string{"1", "2"} {
+		if ev := <-ch; ev.Key != want {
+			t.Fatalf("event = %+v, want key %s", ev, want)
+		}
+	}
+	select {
+	case ev := <-ch:
+		t.Fatalf("unexpected event %+v", ev)
+	default:
+	}
+
+	if st := l.Status(); st.Processed != 1 || st.LastEventAt.IsZero() {
+		t.Fatalf("status = %+v, want only the group message counted as processed", st)
+	}
+}
+
+func TestWatchersFallBackToSubscribe(t *testing.T) {
+	inner := &feedBroker{}
+	// IEventBroker 만 노출해 Feed 를 감춘다
+	broker := struct {
+		_interface.IEventBroker
+	}{inner}
+	l := NewEventListener(broker, newTestService())
+	l.Start(context.Background())
+	ch, cancel := l.Watch(1)
+	defer cancel()
+
+	if err := inner.subscribe(context.Background(), _interface.Message{Topic: "users", Key: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	if ev := <-ch; ev.Key != "1" {
+		t.Fatalf("event = %+v", ev)
+	}
+	if inner.feed != nil {
+		t.Fatal("Feed used by a broker that does not expose it")
+	}
+}
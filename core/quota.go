@@ -0,0 +1,184 @@
+package core
+
+import (
+	"cache/config"
+	"cache/metrics"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// quotaRetryAfter 만료 시각을 알 수 없을 때 권장하는 재시도 간격
+const quotaRetryAfter = time.Minute
+
+// ErrQuotaExceeded topic quota 초과. errors.Is 로 확인하고 상세는 *QuotaError 로 얻는다
+var ErrQuotaExceeded = errors.New("topic quota exceeded")
+
+// QuotaError 어떤 한도를 넘었는지와 재시도 권장 시간
+type QuotaError struct {
+	Topic      string
+	Limit      string // max_keys, max_bytes
+	RetryAfter time.Duration
+}
+
+func (e *QuotaError) Error() string {
+	return fmt.Sprintf("%s [topic=%s, limit=%s]", ErrQuotaExceeded, e.Topic, e.Limit)
+}
+
+func (e *QuotaError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
+
+type quotaEntry struct {
+	size    int64
+	expires time.Time // zero 면 만료 없음
+}
+
+type quotaUsage struct {
+	entries map[string]quotaEntry
+	bytes   int64
+}
+
+// quotaTracker 이 노드에서 쓴 key 를 topic 별로 집계해 quota 를 적용.
+// 다른 노드의 쓰기나 backend 의 eviction 은 알 수 없으므로 근사치다
+type quotaTracker struct {
+	mu     sync.Mutex
+	def    config.TopicQuota
+	topics map[string]config.TopicQuota
+	usage  map[string]*quotaUsage
+	now    func() time.Time
+}
+
+func newQuotaTracker(cfg config.QuotaConfig) *quotaTracker {
+	topics := make(map[string]config.TopicQuota, len(cfg.Topics))
+	for t, q := range cfg.Topics {
+		// viper 가 map key 를 소문자로 바꾸므로 대소문자 구분 없이 찾는다
+		topics[strings.ToLower(t)] = q
+	}
+	return &quotaTracker{def: cfg.Default, topics: topics, usage: make(map[string]*quotaUsage), now: time.Now}
+}
+
+func (q *quotaTracker) limit(topic string) config.TopicQuota {
+	if l, ok := q.topics[strings.ToLower(topic)]; ok {
+		return l
+	}
+	return q.def
+}
+
+// reserve key 의 쓰기를 집계에 반영. 한도를 넘으면 *QuotaError.
+// 반환된 undo 는 쓰기가 실패했을 때 이전 상태로 되돌린다
+func (q *quotaTracker) reserve(topic string, key string, size int64, ttl int) (undo func(), err error) {
+	limit := q.limit(topic)
+	if limit.MaxKeys <= 0 && limit.MaxBytes <= 0 {
+		return func() {}, nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	u, ok := q.usage[topic]
+	if !ok {
+		u = &quotaUsage{entries: make(map[string]quotaEntry)}
+		q.usage[topic] = u
+	}
+	now := q.now()
+	prev, existed := u.entries[key]
+	if existed && !prev.expires.IsZero() && now.After(prev.expires) {
+		u.remove(key)
+		existed = false
+	}
+
+	keys, bytes := len(u.entries), u.bytes+size
+	if existed {
+		bytes -= prev.size
+	} else {
+		keys++
+	}
+	if reason := quotaExceeded(limit, keys, bytes); reason != "" {
+		// 만료된 항목을 정리하고 다시 확인
+		u.prune(now)
+		keys, bytes = len(u.entries), u.bytes+size
+		if existed {
+			bytes -= prev.size
+		} else {
+			keys++
+		}
+		if reason = quotaExceeded(limit, keys, bytes); reason != "" {
+			metrics.Rejections.WithLabelValues("quota_"+reason, metrics.Topic(topic)).Inc()
+			return nil, &QuotaError{Topic: topic, Limit: reason, RetryAfter: u.nextExpiry(now)}
+		}
+	}
+
+	entry := quotaEntry{size: size}
+	if ttl > 0 {
+		entry.expires = now.Add(time.Duration(ttl) * time.Second)
+	}
+	u.put(key, entry)
+
+	return func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		if existed {
+			u.put(key, prev)
+		} else {
+			u.remove(key)
+		}
+	}, nil
+}
+
+// release key 가 무효화되면 집계에서 제외
+func (q *quotaTracker) release(topic string, key string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if u, ok := q.usage[topic]; ok {
+		u.remove(key)
+	}
+}
+
+// quotaExceeded 넘은 한도 이름. 한도 안이면 빈 문자열
+func quotaExceeded(l config.TopicQuota, keys int, bytes int64) string {
+	if l.MaxKeys > 0 && keys > l.MaxKeys {
+		return "max_keys"
+	}
+	if l.MaxBytes > 0 && bytes > l.MaxBytes {
+		return "max_bytes"
+	}
+	return ""
+}
+
+func (u *quotaUsage) put(key string, e quotaEntry) {
+	u.remove(key)
+	u.entries[key] = e
+	u.bytes += e.size
+}
+
+func (u *quotaUsage) remove(key string) {
+	if e, ok := u.entries[key]; ok {
+		u.bytes -= e.size
+		delete(u.entries, key)
+	}
+}
+
+func (u *quotaUsage) prune(now time.Time) {
+	for k, e := range u.entries {
+		if !e.expires.IsZero() && now.After(e.expires) {
+			u.remove(k)
+		}
+	}
+}
+
+// nextExpiry 가장 먼저 만료되는 항목까지의 시간. 만료되는 항목이 없으면 quotaRetryAfter
+func (u *quotaUsage) nextExpiry(now time.Time) time.Duration {
+	var next time.Time
+	for _, e := range u.entries {
+		if !e.expires.IsZero() && (next.IsZero() || e.expires.Before(next)) {
+			next = e.expires
+		}
+	}
+	if next.IsZero() {
+		return quotaRetryAfter
+	}
+	return next.Sub(now)
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"cache/config"
+	"cache/core/cache_adapter"
+	"cache/core/strategy"
+	"cache/tenant"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestQuota(cfg config.QuotaConfig) (*quotaTracker, *time.Time) {
+	now := time.Unix(1_700_000_000, 0)
+	q := newQuotaTracker(cfg)
+	q.now = func() time.Time { return now }
+	return q, &now
+}
+
+func TestQuotaMaxKeys(t *testing.T) {
+	q, _ := newTestQuota(config.QuotaConfig{Topics: map[string]config.TopicQuota{"users": {MaxKeys: 2}}})
+
+	for _, k := range []string{"1", "2", "1"} {
+		if _, err := q.reserve("users", k, 1, 0); err != nil {
+			t.Fatalf("reserve %s: %v", k, err)
+		}
+	}
+	_, err := q.reserve("users", "3", 1, 0)
+	var qe *QuotaError
+	if !errors.As(err, &qe) || !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("err = %v, want QuotaError", err)
+	}
+	if qe.Limit != "max_keys" || qe.RetryAfter != quotaRetryAfter {
+		t.Fatalf("QuotaError = %+v", qe)
+	}
+
+	// 무효화된 key 는 집계에서 빠진다
+	q.release("users", "1")
+	if _, err := q.reserve("users", "3", 1, 0); err != nil {
+		t.Fatalf("reserve after release: %v", err)
+	}
+	// 다른 topic 은 기본값(제한 없음)
+	if _, err := q.reserve("orders", "x", 1, 0); err != nil {
+		t.Fatalf("unlimited topic: %v", err)
+	}
+}
+
+func TestQuotaMaxBytes(t *testing.T) {
+	q, _ := newTestQuota(config.QuotaConfig{Default: config.TopicQuota{MaxBytes: 10}})
+
+	if _, err := q.reserve("users", "a", 6, 0); err != nil {
+		t.Fatal(err)
+	}
+	// 같은 key 를 덮어쓰면 이전 크기를 빼고 계산
+	if _, err := q.reserve("users", "a", 8, 0); err != nil {
+		t.Fatalf("overwrite: %v", err)
+	}
+	_, err := q.reserve("users", "b", 3, 0)
+	var qe *QuotaError
+	if !errors.As(err, &qe) || qe.Limit != "max_bytes" {
+		t.Fatalf("err = %v, want max_bytes", err)
+	}
+}
+
+func TestQuotaExpiredEntries(t *testing.T) {
+	q, now := newTestQuota(config.QuotaConfig{Default: config.TopicQuota{MaxKeys: 1}})
+
+	if _, err := q.reserve("users", "a", 1, 30); err != nil {
+		t.Fatal(err)
+	}
+	*now = now.Add(10 * time.Second)
+	_, err := q.reserve("users", "b", 1, 0)
+	var qe *QuotaError
+	if !errors.As(err, &qe) || qe.RetryAfter != 20*time.Second {
+		t.Fatalf("err = %v, want retry after 20s", err)
+	}
+
+	// TTL 이 지난 항목은 정리 후 다시 확인한다
+	*now = now.Add(21 * time.Second)
+	if _, err := q.reserve("users", "b", 1, 0); err != nil {
+		t.Fatalf("reserve after expiry: %v", err)
+	}
+}
+
+func TestQuotaUndo(t *testing.T) {
+	q, _ := newTestQuota(config.QuotaConfig{Default: config.TopicQuota{MaxKeys: 1}})
+
+	undo, err := q.reserve("users", "a", 1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	undo()
+	if _, err := q.reserve("users", "b", 1, 0); err != nil {
+		t.Fatalf("reserve after undo: %v", err)
+	}
+}
+
+func TestQuotaTopicCaseInsensitive(t *testing.T) {
+	// viper 가 소문자로 바꾼 설정 key 로도 찾는다
+	q, _ := newTestQuota(config.QuotaConfig{Topics: map[string]config.TopicQuota{"orderevents": {MaxKeys: 1}}})
+	if got := q.limit("OrderEvents").MaxKeys; got != 1 {
+		t.Fatalf("MaxKeys = %d, want 1", got)
+	}
+}
+
+func TestServiceQuotaPerTenant(t *testing.T) {
+	cs := NewCacheService(
+		cache_adapter.NewMemoryAdapter(config.MemoryConfig{}),
+		strategy.NewVersionedKeyStrategy(config.InvalidationConfig{}),
+	)
+	cs.SetQuota(config.QuotaConfig{Default: config.TopicQuota{MaxKeys: 1}})
+	resolver := tenant.NewResolver(config.TenancyConfig{
+		Enabled: true,
+		Tenants: []config.TenantConfig{{ID: "acme"}, {ID: "globex"}},
+	})
+
+	// tenant 마다 따로 집계한다
+	for _, id := range []string{"acme", "globex"} {
+		tn, _ := resolver.Lookup(id)
+		ctx := tenant.WithContext(context.Background(), tn)
+		if err := cs.Set(ctx, "users", "1", "v", 0); err != nil {
+			t.Fatalf("%s first Set: %v", id, err)
+		}
+		if err := cs.Set(ctx, "users", "2", "v", 0); !errors.Is(err, ErrQuotaExceeded) {
+			t.Fatalf("%s second Set = %v, want quota error", id, err)
+		}
+	}
+
+	// Invalidate 하면 다시 쓸 수 있다
+	ctx := context.Background()
+	if err := cs.Set(ctx, "users", "1", strings.Repeat("v", 4), 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.Invalidate(ctx, "users", "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cs.Set(ctx, "users", "2", "v", 0); err != nil {
+		t.Fatalf("Set after invalidate: %v", err)
+	}
+}
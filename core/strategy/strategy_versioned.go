@@ -9,12 +9,14 @@ import (
 type versionedStrategy struct {
 	delimiter      string
 	defaultVersion int
+	ttl            topicTTL
 }
 
-func NewVersionedKeyStrategy(cfg config.VersionedStrategy) _interface.IInvalidationStrategy {
+func NewVersionedKeyStrategy(cfg config.InvalidationConfig) _interface.IInvalidationStrategy {
 	return &versionedStrategy{
-		delimiter:      cfg.Delimiter,
-		defaultVersion: cfg.DefaultVersion,
+		delimiter:      cfg.Versioned.Delimiter,
+		defaultVersion: cfg.Versioned.DefaultVersion,
+		ttl:            newTopicTTL(cfg),
 	}
 }
 
@@ -22,8 +24,8 @@ func (v *versionedStrategy) GenerateKey(topic string, key string) string {
 	return topic + ":" + key + v.delimiter + toString(v.defaultVersion)
 }
 
-func (v *versionedStrategy) ComputeTTL(baseTTL int) int {
-	return baseTTL
+func (v *versionedStrategy) ComputeTTL(topic string, baseTTL int) int {
+	return v.ttl.compute(topic, baseTTL)
 }
 
 func toString(i int) string {
@@ -0,0 +1,43 @@
+package strategy
+
+import (
+	"cache/config"
+	"testing"
+)
+
+func TestGenerateKey(t *testing.T) {
+	s := NewVersionedKeyStrategy(config.InvalidationConfig{
+		Versioned: config.VersionedStrategy{Delimiter: ":v", DefaultVersion: 3},
+	})
+	if got := s.GenerateKey("users", "42"); got != "users:42:v3" {
+		t.Fatalf("GenerateKey = %q", got)
+	}
+}
+
+func TestComputeTTL(t *testing.T) {
+	s := NewVersionedKeyStrategy(config.InvalidationConfig{
+		DefaultTTLSeconds: 600,
+		TopicTTLs:         map[string]int{"sessions": 60},
+	})
+
+	tests := []struct {
+		topic string
+		base  int
+		want  int
+	}{
+		{"users", 30, 30},   // 요청 TTL 우선
+		{"sessions", 0, 60}, // topic 별 TTL
+		{"Sessions", 0, 60}, // viper 가 소문자로 바꾼 key 와도 일치
+		{"users", 0, 600},   // 기본 TTL
+	}
+	for _, tt := range tests {
+		if got := s.ComputeTTL(tt.topic, tt.base); got != tt.want {
+			t.Errorf("ComputeTTL(%q, %d) = %d, want %d", tt.topic, tt.base, got, tt.want)
+		}
+	}
+
+	// 설정이 없으면 만료 없음(0)
+	if got := NewVersionedKeyStrategy(config.InvalidationConfig{}).ComputeTTL("users", 0); got != 0 {
+		t.Fatalf("ComputeTTL = %d, want 0", got)
+	}
+}
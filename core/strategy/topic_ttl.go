@@ -0,0 +1,31 @@
+package strategy
+
+import (
+	"cache/config"
+	"strings"
+)
+
+// topicTTL 요청에 TTL 이 없을 때 적용할 topic 별 기본 TTL.
+// viper 가 map key 를 소문자로 바꾸므로 topic 은 대소문자를 구분하지 않는다
+type topicTTL struct {
+	defaultTTL int
+	topics     map[string]int
+}
+
+func newTopicTTL(cfg config.InvalidationConfig) topicTTL {
+	topics := make(map[string]int, len(cfg.TopicTTLs))
+	for t, ttl := range cfg.TopicTTLs {
+		topics[strings.ToLower(t)] = ttl
+	}
+	return topicTTL{defaultTTL: cfg.DefaultTTLSeconds, topics: topics}
+}
+
+func (t topicTTL) compute(topic string, baseTTL int) int {
+	if baseTTL > 0 {
+		return baseTTL
+	}
+	if ttl, ok := t.topics[strings.ToLower(topic)]; ok {
+		return ttl
+	}
+	return t.defaultTTL
+}
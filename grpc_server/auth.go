@@ -0,0 +1,68 @@
+package grpc_server
+
+import (
+	"cache/auth"
+	"context"
+	"net/http"
+	"net/textproto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// authContext metadata 의 x-api-key 또는 authorization(Bearer) 로 인증. guard 가 nil 이면 인증 없이 통과
+func authContext(ctx context.Context, guard *auth.Guard, method string) (context.Context, error) {
+	if guard == nil {
+		return ctx, nil
+	}
+	header := make(http.Header)
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for k, vals := range md {
+			header[textproto.CanonicalMIMEHeaderKey(k)] = vals
+		}
+	}
+	ctx, err := guard.AuthenticateHeader(ctx, remoteAddr(ctx), method, header)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "unauthorized")
+	}
+	return ctx, nil
+}
+
+func authUnaryInterceptor(guard *auth.Guard) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authContext(ctx, guard, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func authStreamInterceptor(guard *auth.Guard) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authContext(ss.Context(), guard, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// allowed topic 에 perm 권한이 없으면 PermissionDenied
+func allowed(ctx context.Context, perm auth.Permission, topic string) error {
+	if !auth.AllowedContext(ctx, perm, topic) {
+		return status.Error(codes.PermissionDenied, "forbidden")
+	}
+	return nil
+}
+
+// remoteAddr 속도 제한에서 인증되지 않은 호출을 구분하는 peer 주소
+func remoteAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return ""
+}
@@ -0,0 +1,24 @@
+package grpc_server
+
+import (
+	"cache/requestid"
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// requestIDUnaryInterceptor metadata 의 x-request-id 를 이어받거나 새로 발급해 ctx 와 응답 헤더에 싣는다
+func requestIDUnaryInterceptor(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	key := strings.ToLower(requestid.Header)
+	var raw string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get(key); len(vals) > 0 {
+			raw = vals[0]
+		}
+	}
+	id := requestid.Accept(raw)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(key, id))
+	return handler(requestid.WithContext(ctx, id), req)
+}
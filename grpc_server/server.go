@@ -0,0 +1,262 @@
+package grpc_server
+
+import (
+	"cache/auth"
+	"cache/core"
+	"cache/handler"
+	_interface "cache/interface"
+	"cache/logger"
+	"cache/proto/cachepb"
+	"cache/tenant"
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type cacheServer struct {
+	cachepb.UnimplementedCacheServiceServer
+	service  *core.CacheService
+	broker   _interface.IEventBroker
+	listener *core.EventListener
+	limits   *handler.RateLimiter
+	log      *zap.SugaredLogger
+}
+
+// NewServer CacheService 를 노출하는 gRPC 서버 생성. HTTP 와 같은 인증/ACL/속도 제한을 적용한다.
+// guard 가 nil 이면 인증 없이, limits 가 nil 이면 속도 제한 없이, tenants 가 nil 이면 tenant 구분 없이 동작
+func NewServer(service *core.CacheService, broker _interface.IEventBroker, listener *core.EventListener, tenants *tenant.Resolver, guard *auth.Guard, limits *handler.RateLimiter) *grpc.Server {
+	// tenant 는 인증된 principal 을 보고 결정하므로 인증 다음에 둔다
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(requestIDUnaryInterceptor, authUnaryInterceptor(guard), tenantUnaryInterceptor(tenants)),
+		grpc.ChainStreamInterceptor(authStreamInterceptor(guard), tenantStreamInterceptor(tenants)),
+	)
+	cachepb.RegisterCacheServiceServer(s, &cacheServer{
+		service:  service,
+		broker:   broker,
+		listener: listener,
+		limits:   limits,
+		log:      logger.Logger,
+	})
+	return s
+}
+
+func (s *cacheServer) Get(ctx context.Context, req *cachepb.GetRequest) (*cachepb.GetResponse, error) {
+	if req.Topic == "" || req.Key == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing topic or key")
+	}
+	if err := allowed(ctx, auth.PermRead, req.Topic); err != nil {
+		return nil, err
+	}
+	val, err := s.service.Get(ctx, req.Topic, req.Key)
+	if errors.Is(err, _interface.ErrNotFound) {
+		return &cachepb.GetResponse{}, nil
+	}
+	if err != nil {
+		return nil, toStatus(err, "failed to get cache")
+	}
+	return &cachepb.GetResponse{Value: val, Found: true}, nil
+}
+
+func (s *cacheServer) Set(ctx context.Context, req *cachepb.SetRequest) (*cachepb.SetResponse, error) {
+	if req.Topic == "" || req.Key == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing topic or key")
+	}
+	if err := allowed(ctx, auth.PermWrite, req.Topic); err != nil {
+		return nil, err
+	}
+	if err := s.allow(ctx, req.Topic); err != nil {
+		return nil, err
+	}
+	if err := s.service.Set(ctx, req.Topic, req.Key, req.Value, int(req.TtlSeconds)); err != nil {
+		return nil, toStatus(err, "failed to set cache")
+	}
+	return &cachepb.SetResponse{}, nil
+}
+
+func (s *cacheServer) Invalidate(ctx context.Context, req *cachepb.InvalidateRequest) (*cachepb.InvalidateResponse, error) {
+	if req.Topic == "" || req.Key == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing topic or key")
+	}
+	if err := allowed(ctx, auth.PermInvalidate, req.Topic); err != nil {
+		return nil, err
+	}
+	if err := s.allow(ctx, req.Topic); err != nil {
+		return nil, err
+	}
+	if err := s.service.Invalidate(ctx, req.Topic, req.Key); err != nil {
+		return nil, toStatus(err, "failed to invalidate")
+	}
+	if err := s.broker.Publish(ctx, req.Topic, req.Key); err != nil {
+		return nil, status.Error(codes.Unavailable, "failed to publish")
+	}
+	return &cachepb.InvalidateResponse{}, nil
+}
+
+func (s *cacheServer) BatchGet(ctx context.Context, req *cachepb.BatchGetRequest) (*cachepb.BatchGetResponse, error) {
+	resp := &cachepb.BatchGetResponse{Items: make([]*cachepb.GetResult, 0, len(req.Items))}
+	for _, item := range req.Items {
+		res := &cachepb.GetResult{Topic: item.Topic, Key: item.Key}
+		if !auth.AllowedContext(ctx, auth.PermRead, item.Topic) {
+			res.Error = "forbidden"
+			resp.Items = append(resp.Items, res)
+			continue
+		}
+		val, err := s.service.Get(ctx, item.Topic, item.Key)
+		switch {
+		case err == nil:
+			res.Value = val
+			res.Found = true
+		case errors.Is(err, _interface.ErrUnavailable):
+			res.Error = "unavailable"
+		case !errors.Is(err, _interface.ErrNotFound):
+			res.Error = "failed to get cache"
+		}
+		resp.Items = append(resp.Items, res)
+	}
+	return resp, nil
+}
+
+func (s *cacheServer) BatchSet(ctx context.Context, req *cachepb.BatchSetRequest) (*cachepb.BatchSetResponse, error) {
+	if err := s.allowBatch(ctx, len(req.Items)); err != nil {
+		return nil, err
+	}
+	resp := &cachepb.BatchSetResponse{Items: make([]*cachepb.Result, 0, len(req.Items))}
+	for _, item := range req.Items {
+		res := &cachepb.Result{Topic: item.Topic, Key: item.Key}
+		if !auth.AllowedContext(ctx, auth.PermWrite, item.Topic) {
+			res.Error = "forbidden"
+		} else if ok, _ := s.limits.AllowTopic(ctx, remoteAddr(ctx), item.Topic); !ok {
+			res.Error = "rate limited"
+		} else if err := s.service.Set(ctx, item.Topic, item.Key, item.Value, int(item.TtlSeconds)); err != nil {
+			res.Error = itemError(err, "failed to set cache")
+		}
+		resp.Items = append(resp.Items, res)
+	}
+	return resp, nil
+}
+
+func (s *cacheServer) BatchInvalidate(ctx context.Context, req *cachepb.BatchInvalidateRequest) (*cachepb.BatchInvalidateResponse, error) {
+	if err := s.allowBatch(ctx, len(req.Items)); err != nil {
+		return nil, err
+	}
+	resp := &cachepb.BatchInvalidateResponse{Items: make([]*cachepb.Result, 0, len(req.Items))}
+	for _, item := range req.Items {
+		res := &cachepb.Result{Topic: item.Topic, Key: item.Key}
+		if !auth.AllowedContext(ctx, auth.PermInvalidate, item.Topic) {
+			res.Error = "forbidden"
+		} else if ok, _ := s.limits.AllowTopic(ctx, remoteAddr(ctx), item.Topic); !ok {
+			res.Error = "rate limited"
+		} else if err := s.service.Invalidate(ctx, item.Topic, item.Key); err != nil {
+			res.Error = itemError(err, "failed to invalidate")
+		} else if err := s.broker.Publish(ctx, item.Topic, item.Key); err != nil {
+			res.Error = "failed to publish"
+		}
+		resp.Items = append(resp.Items, res)
+	}
+	return resp, nil
+}
+
+func (s *cacheServer) WatchInvalidations(req *cachepb.WatchInvalidationsRequest, stream cachepb.CacheService_WatchInvalidationsServer) error {
+	ctx := stream.Context()
+	topics := make(map[string]struct{}, len(req.Topics))
+	for _, t := range req.Topics {
+		if err := allowed(ctx, auth.PermRead, t); err != nil {
+			return err
+		}
+		topics[t] = struct{}{}
+	}
+
+	tenantID := tenant.ID(ctx)
+	events, cancel := s.listener.Watch(256)
+	defer cancel()
+	s.log.Infof("👀 gRPC invalidation watcher connected [topics=%v]", req.Topics)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			s.log.Infof("👋 gRPC invalidation watcher disconnected")
+			return nil
+		case ev, ok := <-events:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, "watcher too slow, events dropped")
+			}
+			if ev.Tenant != tenantID || !auth.Can(ctx, auth.PermRead, ev.Topic) {
+				continue
+			}
+			if len(topics) > 0 {
+				if _, match := topics[ev.Topic]; !match {
+					continue
+				}
+			}
+			err := stream.Send(&cachepb.InvalidationEvent{
+				Topic:           ev.Topic,
+				Key:             ev.Key,
+				TimestampUnixMs: ev.Time.UnixMilli(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// allow 단건 쓰기의 client/topic 속도 제한
+func (s *cacheServer) allow(ctx context.Context, topic string) error {
+	if ok, wait := s.limits.AllowClient(ctx, remoteAddr(ctx), topic, 1); !ok {
+		return rateLimited(ctx, wait, "rate limit exceeded")
+	}
+	if ok, wait := s.limits.AllowTopic(ctx, remoteAddr(ctx), topic); !ok {
+		return rateLimited(ctx, wait, "topic rate limit exceeded")
+	}
+	return nil
+}
+
+// allowBatch 배치 전체를 client bucket 에서 항목 수만큼 차감. topic 제한은 항목별로 검사
+func (s *cacheServer) allowBatch(ctx context.Context, n int) error {
+	if ok, wait := s.limits.AllowClient(ctx, remoteAddr(ctx), "", n); !ok {
+		return rateLimited(ctx, wait, "rate limit exceeded")
+	}
+	return nil
+}
+
+// rateLimited ResourceExhausted 와 함께 다시 시도할 때까지의 초를 retry-after 헤더로 알린다
+func rateLimited(ctx context.Context, wait time.Duration, msg string) error {
+	secs := int(math.Ceil(wait.Seconds()))
+	if secs < 1 {
+		secs = 1
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(secs)))
+	return status.Error(codes.ResourceExhausted, msg)
+}
+
+// toStatus CacheService 에러를 gRPC 상태로 변환. 알 수 없는 에러는 msg 와 함께 Internal
+func toStatus(err error, msg string) error {
+	switch {
+	case errors.Is(err, core.ErrQuotaExceeded):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, _interface.ErrUnavailable):
+		return status.Error(codes.Unavailable, "cache backend unavailable")
+	default:
+		return status.Error(codes.Internal, msg)
+	}
+}
+
+// itemError 배치 항목 오류 메시지. HTTP 배치 API 와 같은 문구를 쓴다
+func itemError(err error, msg string) string {
+	switch {
+	case errors.Is(err, core.ErrQuotaExceeded):
+		return "quota exceeded"
+	case errors.Is(err, _interface.ErrUnavailable):
+		return "unavailable"
+	default:
+		return msg
+	}
+}
@@ -0,0 +1,246 @@
+package grpc_server
+
+import (
+	"cache/auth"
+	"cache/config"
+	"cache/core"
+	"cache/core/cache_adapter"
+	"cache/core/strategy"
+	_interface "cache/interface"
+	"cache/proto/cachepb"
+	"cache/tenant"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type nopBroker struct{}
+
+func (nopBroker) Publish(context.Context, string, string) error              { return nil }
+func (nopBroker) Subscribe(context.Context, _interface.MessageHandler) error { return nil }
+func (nopBroker) Ping(context.Context) error                                 { return nil }
+func (nopBroker) ConsumerStatus() _interface.ConsumerStatus                  { return _interface.ConsumerStatus{} }
+func (nopBroker) Close() error                                               { return nil }
+
+func newTestGuard(t *testing.T) *auth.Guard {
+	t.Helper()
+	guard, err := auth.NewGuard(context.Background(), config.AuthConfig{
+		Enabled: true,
+		APIKeys: []config.APIKeyConfig{{ID: "reader", Key: "secret"}},
+		ACL:     []config.ACLRule{{Principal: "reader", Topics: []string{"users"}, Permissions: []string{"read"}}},
+		Audit:   config.AuditConfig{File: filepath.Join(t.TempDir(), "audit.log")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = guard.Close() })
+	return guard
+}
+
+// newTestClient bufconn 위에서 서버를 띄우고 client 를 반환
+func newTestClient(t *testing.T, guard *auth.Guard, tenants *tenant.Resolver) cachepb.CacheServiceClient {
+	t.Helper()
+	service := core.NewCacheService(
+		cache_adapter.NewMemoryAdapter(config.MemoryConfig{}),
+		strategy.NewVersionedKeyStrategy(config.InvalidationConfig{}),
+	)
+	srv := NewServer(service, nopBroker{}, core.NewEventListener(nil, nil), tenants, guard, nil)
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return cachepb.NewCacheServiceClient(conn)
+}
+
+func withAPIKey(key string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+}
+
+func TestRejectsUnauthenticatedCalls(t *testing.T) {
+	client := newTestClient(t, newTestGuard(t), nil)
+
+	for name, ctx := range map[string]context.Context{
+		"no credentials": context.Background(),
+		"wrong key":      withAPIKey("nope"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := client.Get(ctx, &cachepb.GetRequest{Topic: "users", Key: "1"})
+			if status.Code(err) != codes.Unauthenticated {
+				t.Fatalf("code = %v, want Unauthenticated", status.Code(err))
+			}
+		})
+	}
+}
+
+func TestAppliesACL(t *testing.T) {
+	client := newTestClient(t, newTestGuard(t), nil)
+	ctx := withAPIKey("secret")
+
+	if _, err := client.Get(ctx, &cachepb.GetRequest{Topic: "users", Key: "1"}); err != nil {
+		t.Fatalf("Get allowed topic: %v", err)
+	}
+	if _, err := client.Get(ctx, &cachepb.GetRequest{Topic: "orders", Key: "1"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Get code = %v, want PermissionDenied", status.Code(err))
+	}
+	if _, err := client.Set(ctx, &cachepb.SetRequest{Topic: "users", Key: "1", Value: "v"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Set code = %v, want PermissionDenied", status.Code(err))
+	}
+
+	resp, err := client.BatchGet(ctx, &cachepb.BatchGetRequest{Items: []*cachepb.Key{
+		{Topic: "users", Key: "1"},
+		{Topic: "orders", Key: "1"},
+	}})
+	if err != nil {
+		t.Fatalf("BatchGet: %v", err)
+	}
+	if got := resp.Items[0].Error; got != "" {
+		t.Fatalf("allowed item error = %q", got)
+	}
+	if got := resp.Items[1].Error; got != "forbidden" {
+		t.Fatalf("forbidden item error = %q, want forbidden", got)
+	}
+}
+
+func TestResolvesTenantFromPrincipal(t *testing.T) {
+	tenants := tenant.NewResolver(config.TenancyConfig{
+		Enabled: true,
+		Tenants: []config.TenantConfig{
+			{ID: "acme", Principals: []string{"reader"}},
+			{ID: "globex"},
+		},
+	})
+	client := newTestClient(t, newTestGuard(t), tenants)
+
+	ctx := withAPIKey("secret")
+	if _, err := client.Get(ctx, &cachepb.GetRequest{Topic: "users", Key: "1"}); err != nil {
+		t.Fatalf("Get pinned tenant: %v", err)
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-tenant-id", "globex")
+	if _, err := client.Get(ctx, &cachepb.GetRequest{Topic: "users", Key: "1"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("code = %v, want PermissionDenied", status.Code(err))
+	}
+}
+
+func TestWatchRejectsForbiddenTopics(t *testing.T) {
+	client := newTestClient(t, newTestGuard(t), nil)
+
+	stream, err := client.WatchInvalidations(withAPIKey("secret"), &cachepb.WatchInvalidationsRequest{Topics: []string{"orders"}})
+	if err != nil {
+		t.Fatalf("WatchInvalidations: %v", err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("code = %v, want PermissionDenied", status.Code(err))
+	}
+}
+
+func TestSetGetInvalidate(t *testing.T) {
+	client := newTestClient(t, nil, nil)
+	ctx := context.Background()
+
+	if _, err := client.Set(ctx, &cachepb.SetRequest{Topic: "users", Key: "1", Value: "alice", TtlSeconds: 60}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Get(ctx, &cachepb.GetRequest{Topic: "users", Key: "1"})
+	if err != nil || !resp.Found || resp.Value != "alice" {
+		t.Fatalf("Get = %v, %v", resp, err)
+	}
+	if _, err := client.Invalidate(ctx, &cachepb.InvalidateRequest{Topic: "users", Key: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	// 미스는 에러가 아니라 found=false
+	resp, err = client.Get(ctx, &cachepb.GetRequest{Topic: "users", Key: "1"})
+	if err != nil || resp.Found {
+		t.Fatalf("Get after Invalidate = %v, %v", resp, err)
+	}
+}
+
+func TestRejectsMissingTopicOrKey(t *testing.T) {
+	client := newTestClient(t, nil, nil)
+	_, err := client.Get(context.Background(), &cachepb.GetRequest{Topic: "users"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestBatch(t *testing.T) {
+	client := newTestClient(t, nil, nil)
+	ctx := context.Background()
+
+	set, err := client.BatchSet(ctx, &cachepb.BatchSetRequest{Items: []*cachepb.SetRequest{
+		{Topic: "users", Key: "1", Value: "a"},
+		{Topic: "users", Key: "2", Value: "b"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(set.Items) != 2 || set.Items[0].Error != "" || set.Items[1].Error != "" {
+		t.Fatalf("BatchSet = %v", set.Items)
+	}
+
+	got, err := client.BatchGet(ctx, &cachepb.BatchGetRequest{Items: []*cachepb.Key{{Topic: "users", Key: "1"}, {Topic: "users", Key: "9"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Items[0].Found || got.Items[0].Value != "a" || got.Items[1].Found {
+		t.Fatalf("BatchGet = %v", got.Items)
+	}
+
+	if _, err := client.BatchInvalidate(ctx, &cachepb.BatchInvalidateRequest{Items: []*cachepb.Key{{Topic: "users", Key: "1"}}}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := client.Get(ctx, &cachepb.GetRequest{Topic: "users", Key: "1"})
+	if err != nil || resp.Found {
+		t.Fatalf("Get after BatchInvalidate = %v, %v", resp, err)
+	}
+}
+
+func TestEchoesRequestID(t *testing.T) {
+	client := newTestClient(t, nil, nil)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-123")
+
+	var header metadata.MD
+	if _, err := client.Get(ctx, &cachepb.GetRequest{Topic: "users", Key: "1"}, grpc.Header(&header)); err != nil {
+		t.Fatal(err)
+	}
+	if got := header.Get("x-request-id"); len(got) != 1 || got[0] != "req-123" {
+		t.Fatalf("x-request-id = %v", got)
+	}
+}
+
+func TestItemError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"unavailable", fmt.Errorf("redis del: %w", _interface.ErrUnavailable), "unavailable"},
+		{"quota", &core.QuotaError{Topic: "users", Limit: "max_keys", RetryAfter: time.Second}, "quota exceeded"},
+		{"unknown", errors.New("boom"), "failed to invalidate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := itemError(tt.err, "failed to invalidate"); got != tt.want {
+				t.Fatalf("itemError = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package grpc_server
+
+import (
+	"cache/auth"
+	"cache/tenant"
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// tenantContext 인증된 principal 에 고정된 tenant, 없으면 metadata 의 tenant 헤더 또는 기본 tenant 를 사용
+func tenantContext(ctx context.Context, tenants *tenant.Resolver) (context.Context, error) {
+	if tenants == nil {
+		return ctx, nil
+	}
+	var requested string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get(strings.ToLower(tenants.Header())); len(vals) > 0 {
+			requested = vals[0]
+		}
+	}
+	p, _ := auth.PrincipalFrom(ctx)
+	t, err := tenants.Resolve(p.Subject, requested)
+	if errors.Is(err, tenant.ErrUnknownTenant) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	return tenant.WithContext(ctx, t), nil
+}
+
+func tenantUnaryInterceptor(tenants *tenant.Resolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := tenantContext(ctx, tenants)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func tenantStreamInterceptor(tenants *tenant.Resolver) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := tenantContext(ss.Context(), tenants)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// contextStream interceptor 가 만든 context 를 돌려주는 ServerStream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"cache/apierror"
+	"cache/core/event_broker"
+	"cache/interface"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+const defaultDeadLetterLimit = 100
+
+// DeadLetterListHandler DLQ 메시지 조회. ?limit=N (기본 100, 0 이면 전체)
+func DeadLetterListHandler(dlq _interface.IDeadLetterQueue) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := defaultDeadLetterLimit
+		if raw := r.URL.Query().Get("limit"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 0 {
+				writeBadRequest(w, r, "invalid limit")
+				return
+			}
+			limit = n
+		}
+
+		letters, err := dlq.DeadLetters(r.Context(), limit)
+		if err != nil {
+			writeDeadLetterError(w, r, err, "failed to read dead letters")
+			return
+		}
+		if letters == nil {
+			letters = []_interface.DeadLetter{}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(letters); err != nil {
+			return
+		}
+	}
+}
+
+// DeadLetterReplayHandler DLQ 메시지를 원래 topic 으로 재발행
+func DeadLetterReplayHandler(dlq _interface.IDeadLetterQueue) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		partition, err := strconv.Atoi(urlParam(r, "partition"))
+		if err != nil {
+			writeBadRequest(w, r, "invalid partition")
+			return
+		}
+		offset, err := strconv.ParseInt(urlParam(r, "offset"), 10, 64)
+		if err != nil {
+			writeBadRequest(w, r, "invalid offset")
+			return
+		}
+
+		if err := dlq.ReplayDeadLetter(r.Context(), partition, offset); err != nil {
+			writeDeadLetterError(w, r, err, "failed to replay dead letter")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func writeDeadLetterError(w http.ResponseWriter, r *http.Request, err error, msg string) {
+	if errors.Is(err, event_broker.ErrDeadLetterDisabled) || errors.Is(err, event_broker.ErrDeadLetterNotFound) {
+		apierror.Write(w, r, http.StatusNotFound, apierror.CodeNotFound, err.Error())
+		return
+	}
+	apierror.Write(w, r, http.StatusInternalServerError, apierror.CodeInternal, msg)
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"cache/core/event_broker"
+	_interface "cache/interface"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// fakeDLQ 고정된 dead letter 목록과 재발행 기록
+type fakeDLQ struct {
+	letters  []_interface.DeadLetter
+	limit    int
+	replayed []string
+	err      error
+}
+
+func (f *fakeDLQ) DeadLetters(_ context.Context, limit int) ([]_interface.DeadLetter, error) {
+	f.limit = limit
+	return f.letters, f.err
+}
+
+func (f *fakeDLQ) ReplayDeadLetter(_ context.Context, partition int, offset int64) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.replayed = append(f.replayed, fmt.Sprintf("%d/%d", partition, offset))
+	return nil
+}
+
+func newAdminRouter(dlq _interface.IDeadLetterQueue) http.Handler {
+	r := chi.NewRouter()
+	r.Get("/v1/admin/dlq", DeadLetterListHandler(dlq))
+	r.Post("/v1/admin/dlq/{partition}/{offset}/replay", DeadLetterReplayHandler(dlq))
+	return r
+}
+
+func TestDeadLetterList(t *testing.T) {
+	dlq := &fakeDLQ{letters: []_interface.DeadLetter{{Partition: 0, Offset: 42, Topic: "users", Key: "1"}}}
+	router := newAdminRouter(dlq)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/admin/dlq?limit=5", nil))
+	var letters []_interface.DeadLetter
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &letters) != nil || len(letters) != 1 || letters[0].Offset != 42 {
+		t.Fatalf("list = %d %s", rec.Code, rec.Body)
+	}
+	if dlq.limit != 5 {
+		t.Fatalf("limit = %d, want 5", dlq.limit)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/admin/dlq?limit=-1", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("negative limit = %d, want 400", rec.Code)
+	}
+}
+
+func TestDeadLetterListEmptyIsArray(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newAdminRouter(&fakeDLQ{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/admin/dlq", nil))
+	if body := rec.Body.String(); body != "[]\n" {
+		t.Fatalf("body = %q, want []", body)
+	}
+}
+
+func TestDeadLetterReplay(t *testing.T) {
+	dlq := &fakeDLQ{}
+	router := newAdminRouter(dlq)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/admin/dlq/0/42/replay", nil))
+	if rec.Code != http.StatusNoContent || len(dlq.replayed) != 1 || dlq.replayed[0] != "0/42" {
+		t.Fatalf("replay = %d, replayed %v", rec.Code, dlq.replayed)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/admin/dlq/x/42/replay", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("bad partition = %d, want 400", rec.Code)
+	}
+
+	dlq.err = fmt.Errorf("%w: 0/43", event_broker.ErrDeadLetterNotFound)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/v1/admin/dlq/0/43/replay", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("missing letter = %d, want 404", rec.Code)
+	}
+}
@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"cache/auth"
+	"cache/core"
+	"cache/interface"
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+type batchKey struct {
+	Topic string `json:"topic"`
+	Key   string `json:"key"`
+}
+
+type batchSetItem struct {
+	Topic string `json:"topic"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	TTL   int    `json:"ttl"`
+}
+
+type batchGetResult struct {
+	Topic string `json:"topic"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Found bool   `json:"found"`
+	Error string `json:"error,omitempty"`
+}
+
+type batchResult struct {
+	Topic string `json:"topic"`
+	Key   string `json:"key"`
+	Error string `json:"error,omitempty"`
+}
+
+func BatchGetHandler(service *core.CacheService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Items []batchKey `json:"items"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeBodyError(w, r, err, "invalid json")
+			return
+		}
+
+		results := make([]batchGetResult, 0, len(req.Items))
+		for _, item := range req.Items {
+			res := batchGetResult{Topic: item.Topic, Key: item.Key}
+			if !auth.Allowed(r, auth.PermRead, item.Topic) {
+				res.Error = "forbidden"
+				results = append(results, res)
+				continue
+			}
+			val, err := service.Get(r.Context(), item.Topic, item.Key)
+			switch {
+			case err == nil:
+				res.Value = val
+				res.Found = true
+			case !errors.Is(err, _interface.ErrNotFound):
+				res.Error = itemError(err, "failed to get cache")
+			}
+			results = append(results, res)
+		}
+		writeJSON(w, map[string]interface{}{"items": results})
+	}
+}
+
+func BatchSetHandler(service *core.CacheService, limits *RateLimiter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Items []batchSetItem `json:"items"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeBodyError(w, r, err, "invalid json")
+			return
+		}
+		if !limits.allowBatch(w, r, len(req.Items)) {
+			return
+		}
+
+		results := make([]batchResult, 0, len(req.Items))
+		for _, item := range req.Items {
+			res := batchResult{Topic: item.Topic, Key: item.Key}
+			if !auth.Allowed(r, auth.PermWrite, item.Topic) {
+				res.Error = "forbidden"
+			} else if msg := limits.allowItem(r, item.Topic); msg != "" {
+				res.Error = msg
+			} else if err := service.Set(r.Context(), item.Topic, item.Key, item.Value, item.TTL); err != nil {
+				res.Error = itemError(err, "failed to set cache")
+			}
+			results = append(results, res)
+		}
+		writeJSON(w, map[string]interface{}{"items": results})
+	}
+}
+
+func BatchInvalidateHandler(service *core.CacheService, broker _interface.IEventBroker, limits *RateLimiter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Items []batchKey `json:"items"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeBodyError(w, r, err, "invalid json")
+			return
+		}
+		if !limits.allowBatch(w, r, len(req.Items)) {
+			return
+		}
+
+		results := make([]batchResult, 0, len(req.Items))
+		for _, item := range req.Items {
+			res := batchResult{Topic: item.Topic, Key: item.Key}
+			if !auth.Allowed(r, auth.PermInvalidate, item.Topic) {
+				res.Error = "forbidden"
+			} else if msg := limits.allowItem(r, item.Topic); msg != "" {
+				res.Error = msg
+			} else if err := service.Invalidate(r.Context(), item.Topic, item.Key); err != nil {
+				res.Error = itemError(err, "failed to invalidate")
+			} else if err := broker.Publish(r.Context(), item.Topic, item.Key); err != nil {
+				res.Error = "failed to publish"
+			}
+			results = append(results, res)
+		}
+		writeJSON(w, map[string]interface{}{"items": results})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		return
+	}
+}
@@ -1,35 +1,75 @@
 package handler
 
 import (
+	"cache/apierror"
+	"cache/auth"
 	"cache/core"
 	"cache/interface"
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/url"
 
 	"github.com/go-chi/chi/v5"
 )
 
+type cacheValue struct {
+	Topic string `json:"topic"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// GetCacheHandler 값을 조회. 미스면 404, backend 장애면 503
 func GetCacheHandler(service *core.CacheService) http.HandlerFunc {
+	return getCacheHandler(service, func(w http.ResponseWriter, topic, key, val string) {
+		writeJSON(w, cacheValue{Topic: topic, Key: key, Value: val})
+	})
+}
+
+// LegacyGetCacheHandler /v1 이전 경로용. 값을 JSON 이 아닌 본문 그대로 돌려준다
+func LegacyGetCacheHandler(service *core.CacheService) http.HandlerFunc {
+	return getCacheHandler(service, func(w http.ResponseWriter, _, _, val string) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		_, _ = w.Write([]byte(val))
+	})
+}
+
+func getCacheHandler(service *core.CacheService, write func(w http.ResponseWriter, topic, key, val string)) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		topic := chi.URLParam(r, "topic")
-		key := chi.URLParam(r, "key")
-		val, err := service.Get(topic, key)
+		topic, key, ok := cacheTarget(w, r)
+		if !ok {
+			return
+		}
+		if !auth.Allowed(r, auth.PermRead, topic) {
+			writeForbidden(w, r)
+			return
+		}
+		val, err := service.Get(r.Context(), topic, key)
 		if err != nil {
-			http.Error(w, "failed to get cache", http.StatusInternalServerError)
+			writeServiceError(w, r, err, "failed to get cache")
 			return
 		}
-		w.Write([]byte(val))
+		write(w, topic, key, val)
 	}
 }
 
-func SetCacheHandler(service *core.CacheService) http.HandlerFunc {
+// SetCacheHandler 값을 저장. 다른 노드로 invalidation 을 전파하지 않는다
+func SetCacheHandler(service *core.CacheService, limits *RateLimiter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		topic := chi.URLParam(r, "topic")
-		key := chi.URLParam(r, "key")
+		topic, key, ok := cacheTarget(w, r)
+		if !ok {
+			return
+		}
+		if !auth.Allowed(r, auth.PermWrite, topic) {
+			writeForbidden(w, r)
+			return
+		}
+		if !limits.allow(w, r, topic) {
+			return
+		}
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
-			http.Error(w, "invalid body", http.StatusBadRequest)
+			writeBodyError(w, r, err, "invalid body")
 			return
 		}
 		var payload struct {
@@ -37,34 +77,67 @@ func SetCacheHandler(service *core.CacheService) http.HandlerFunc {
 			TTL   int    `json:"ttl"`
 		}
 		if err := json.Unmarshal(body, &payload); err != nil {
-			http.Error(w, "invalid json", http.StatusBadRequest)
+			writeBadRequest(w, r, "invalid json")
 			return
 		}
-		if err := service.Set(topic, key, payload.Value, payload.TTL); err != nil {
-			http.Error(w, "failed to set cache", http.StatusInternalServerError)
+		if err := service.Set(r.Context(), topic, key, payload.Value, payload.TTL); err != nil {
+			writeServiceError(w, r, err, "failed to set cache")
 			return
 		}
-		w.WriteHeader(http.StatusOK)
+		w.WriteHeader(http.StatusNoContent)
 	}
 }
 
-func InvalidateHandler(service *core.CacheService, broker _interface.IEventBroker) http.HandlerFunc {
+// InvalidateHandler 이 노드에서 무효화한 뒤 broker 로 다른 노드에 전파
+func InvalidateHandler(service *core.CacheService, broker _interface.IEventBroker, limits *RateLimiter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		topic := chi.URLParam(r, "topic")
-		key := chi.URLParam(r, "key")
+		topic, key, ok := cacheTarget(w, r)
+		if !ok {
+			return
+		}
+		if !auth.Allowed(r, auth.PermInvalidate, topic) {
+			writeForbidden(w, r)
+			return
+		}
+		if !limits.allow(w, r, topic) {
+			return
+		}
 
 		// 무효화 처리
-		if err := service.Invalidate(topic, key); err != nil {
-			http.Error(w, "failed to invalidate", http.StatusInternalServerError)
+		if err := service.Invalidate(r.Context(), topic, key); err != nil {
+			writeServiceError(w, r, err, "failed to invalidate")
 			return
 		}
 
 		// Kafka 브로드캐스트
-		if err := broker.Publish(topic, key); err != nil {
-			http.Error(w, "failed to publish", http.StatusInternalServerError)
+		if err := broker.Publish(r.Context(), topic, key); err != nil {
+			apierror.Write(w, r, http.StatusServiceUnavailable, apierror.CodeUnavailable, "failed to publish")
 			return
 		}
 
-		w.WriteHeader(http.StatusOK)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// cacheTarget /cache/{topic}/{key} 경로 또는 /cache?topic=&key= 쿼리에서 대상을 읽는다.
+// 둘 다 없으면 400 으로 응답하고 false
+func cacheTarget(w http.ResponseWriter, r *http.Request) (topic string, key string, ok bool) {
+	topic, key = urlParam(r, "topic"), urlParam(r, "key")
+	if topic == "" && key == "" {
+		topic, key = r.URL.Query().Get("topic"), r.URL.Query().Get("key")
+	}
+	if topic == "" || key == "" {
+		writeBadRequest(w, r, "missing topic or key")
+		return "", "", false
+	}
+	return topic, key, true
+}
+
+// urlParam chi 는 RawPath 로 라우팅하므로 인코딩된 "/" 등을 복원해 사용
+func urlParam(r *http.Request, name string) string {
+	raw := chi.URLParam(r, name)
+	if val, err := url.PathUnescape(raw); err == nil {
+		return val
 	}
+	return raw
 }
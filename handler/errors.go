@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"cache/apierror"
+	"cache/core"
+	"cache/interface"
+	"errors"
+	"net/http"
+)
+
+// writeServiceError CacheService 에러를 상태 코드로 변환. 알 수 없는 에러는 msg 와 함께 500
+func writeServiceError(w http.ResponseWriter, r *http.Request, err error, msg string) {
+	var qe *core.QuotaError
+	switch {
+	case errors.As(err, &qe):
+		writeTooManyRequests(w, r, qe.RetryAfter, apierror.CodeQuotaExceeded, qe.Error())
+	case errors.Is(err, _interface.ErrNotFound):
+		apierror.Write(w, r, http.StatusNotFound, apierror.CodeNotFound, "not found")
+	case errors.Is(err, _interface.ErrUnavailable):
+		apierror.Write(w, r, http.StatusServiceUnavailable, apierror.CodeUnavailable, "cache backend unavailable")
+	default:
+		apierror.Write(w, r, http.StatusInternalServerError, apierror.CodeInternal, msg)
+	}
+}
+
+// writeBodyError body 를 읽거나 해석하지 못했을 때. 크기 제한 초과는 413, 그 외는 msg 와 함께 400
+func writeBodyError(w http.ResponseWriter, r *http.Request, err error, msg string) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		apierror.Write(w, r, http.StatusRequestEntityTooLarge, apierror.CodePayloadTooLarge, "request body too large")
+		return
+	}
+	apierror.Write(w, r, http.StatusBadRequest, apierror.CodeInvalidRequest, msg)
+}
+
+// itemError 배치 항목의 error 문자열. 단건 API 의 상태 코드 구분을 따른다
+func itemError(err error, msg string) string {
+	switch {
+	case errors.Is(err, core.ErrQuotaExceeded):
+		return "quota exceeded"
+	case errors.Is(err, _interface.ErrUnavailable):
+		return "unavailable"
+	default:
+		return msg
+	}
+}
+
+// writeBadRequest 요청 검증 실패
+func writeBadRequest(w http.ResponseWriter, r *http.Request, msg string) {
+	apierror.Write(w, r, http.StatusBadRequest, apierror.CodeInvalidRequest, msg)
+}
+
+// writeForbidden ACL 거부
+func writeForbidden(w http.ResponseWriter, r *http.Request) {
+	apierror.Write(w, r, http.StatusForbidden, apierror.CodeForbidden, "forbidden")
+}
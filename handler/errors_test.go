@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"cache/apierror"
+	"cache/core"
+	_interface "cache/interface"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWriteServiceError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"miss", _interface.ErrNotFound, http.StatusNotFound, apierror.CodeNotFound},
+		{"unavailable", fmt.Errorf("redis get: %w", _interface.ErrUnavailable), http.StatusServiceUnavailable, apierror.CodeUnavailable},
+		{"quota", &core.QuotaError{Topic: "users", Limit: "max_keys", RetryAfter: 2 * time.Second}, http.StatusTooManyRequests, apierror.CodeQuotaExceeded},
+		{"unknown", errors.New("boom"), http.StatusInternalServerError, apierror.CodeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			writeServiceError(w, httptest.NewRequest(http.MethodGet, "/", nil), tt.err, "failed")
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			var body apierror.Body
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil || body.Code != tt.wantCode {
+				t.Fatalf("code = %q (%v), want %q", body.Code, err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestUnknownRouteIsNotACacheMiss(t *testing.T) {
+	router := newSpecRouter()
+
+	tests := []struct {
+		method   string
+		path     string
+		wantCode string
+	}{
+		{http.MethodGet, "/v1/nope", apierror.CodeRouteNotFound},
+		{http.MethodGet, "/v1/cache/users/missing", apierror.CodeNotFound},
+		{http.MethodPatch, "/v1/cache/users/1", apierror.CodeMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			var body apierror.Body
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body.Code != tt.wantCode || body.RequestID == "" {
+				t.Fatalf("body = %+v, want code %q with request id", body, tt.wantCode)
+			}
+		})
+	}
+}
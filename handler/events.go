@@ -0,0 +1,309 @@
+package handler
+
+import (
+	"cache/auth"
+	"cache/config"
+	"cache/core"
+	"cache/logger"
+	"cache/tenant"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const streamWriteTimeout = 5 * time.Second
+
+// withStreamDefaults 설정이 비어 있을 때의 기본값
+func withStreamDefaults(cfg config.StreamConfig) config.StreamConfig {
+	if cfg.ClientBuffer <= 0 {
+		cfg.ClientBuffer = 256
+	}
+	if cfg.HeartbeatSeconds <= 0 {
+		cfg.HeartbeatSeconds = 15
+	}
+	return cfg
+}
+
+type streamEvent struct {
+	ID        string `json:"id"`   // 재개용 ID. 해석하지 말고 Last-Event-ID 로 그대로 돌려보낸다
+	Type      string `json:"type"` // invalidate, reset
+	Topic     string `json:"topic,omitempty"`
+	Key       string `json:"key,omitempty"`
+	Timestamp int64  `json:"timestamp_ms,omitempty"`
+}
+
+// eventFilter ?topic=a&topic=b&prefix=user: 형식의 구독 필터.
+// 요청 tenant 와 다른 tenant 의 이벤트, read 권한이 없는 topic 의 이벤트는 항상 제외된다
+type eventFilter struct {
+	ctx    context.Context
+	tenant string
+	topics map[string]struct{}
+	prefix string
+}
+
+// newEventFilter 명시적으로 요청한 topic 중 read 권한이 없는 것이 있으면 false
+func newEventFilter(r *http.Request) (eventFilter, bool) {
+	f := eventFilter{ctx: r.Context(), tenant: tenant.ID(r.Context()), topics: make(map[string]struct{}), prefix: r.URL.Query().Get("prefix")}
+	for _, t := range r.URL.Query()["topic"] {
+		if !auth.Allowed(r, auth.PermRead, t) {
+			return f, false
+		}
+		f.topics[t] = struct{}{}
+	}
+	return f, true
+}
+
+// match ev.Topic 은 Kafka topic 이 아니라 발행 측이 실어 보낸 캐시 topic 이다
+func (f eventFilter) match(ev core.InvalidationEvent) bool {
+	// 다른 tenant 의 이벤트는 보내지 않는다
+	if ev.Tenant != f.tenant {
+		return false
+	}
+	if !auth.Can(f.ctx, auth.PermRead, ev.Topic) {
+		return false
+	}
+	if len(f.topics) > 0 {
+		if _, ok := f.topics[ev.Topic]; !ok {
+			return false
+		}
+	}
+	return strings.HasPrefix(ev.Key, f.prefix)
+}
+
+// lastEventID EventSource 재연결 헤더 또는 last_event_id 쿼리
+func lastEventID(r *http.Request) string {
+	if raw := r.Header.Get("Last-Event-ID"); raw != "" {
+		return raw
+	}
+	return r.URL.Query().Get("last_event_id")
+}
+
+func toStreamEvent(listener *core.EventListener, ev core.InvalidationEvent) streamEvent {
+	return streamEvent{
+		ID:        listener.EventID(ev.ID),
+		Type:      "invalidate",
+		Topic:     ev.Topic,
+		Key:       ev.Key,
+		Timestamp: ev.Time.UnixMilli(),
+	}
+}
+
+// resetEvent 클라이언트 캐시 전체 초기화 알림. 이후 재개는 가장 최근 이벤트부터
+func resetEvent(listener *core.EventListener, history []core.InvalidationEvent) streamEvent {
+	ev := streamEvent{Type: "reset", ID: listener.EventID(0)}
+	if len(history) > 0 {
+		ev.ID = listener.EventID(history[len(history)-1].ID)
+	}
+	return ev
+}
+
+// InvalidationStreamHandler invalidation 이벤트를 Server-Sent Events 로 전달
+func InvalidationStreamHandler(listener *core.EventListener, cfg config.StreamConfig) http.HandlerFunc {
+	cfg = withStreamDefaults(cfg)
+	return func(w http.ResponseWriter, r *http.Request) {
+		filter, ok := newEventFilter(r)
+		if !ok {
+			writeForbidden(w, r)
+			return
+		}
+		rc := http.NewResponseController(w)
+		// 장기 연결이므로 서버 read/write timeout 대신 쓰기마다 deadline 을 갱신한다
+		_ = rc.SetReadDeadline(time.Time{})
+		_ = rc.SetWriteDeadline(time.Time{})
+
+		replay, complete, events, cancel := listener.WatchFrom(lastEventID(r), cfg.ClientBuffer)
+		defer cancel()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+
+		write := func(ev streamEvent) error {
+			// 느린 클라이언트가 쓰기를 오래 붙잡지 못하도록 deadline 적용
+			_ = rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			data, err := json.Marshal(ev)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", ev.ID, ev.Type, data); err != nil {
+				return err
+			}
+			return rc.Flush()
+		}
+
+		if !complete {
+			// 놓친 이벤트를 복구할 수 없으므로 클라이언트 캐시 전체를 비우도록 알림
+			if err := write(resetEvent(listener, replay)); err != nil {
+				return
+			}
+			replay = nil
+		}
+		for _, ev := range replay {
+			if !filter.match(ev) {
+				continue
+			}
+			if err := write(toStreamEvent(listener, ev)); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+
+		heartbeat := time.NewTicker(time.Duration(cfg.HeartbeatSeconds) * time.Second)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-heartbeat.C:
+				_ = rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+				if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+					return
+				}
+				if err := rc.Flush(); err != nil {
+					return
+				}
+			case ev, ok := <-events:
+				if !ok {
+					// 버퍼 초과로 구독 해제됨: 클라이언트가 Last-Event-ID 로 재연결
+					logger.Logger.Warnf("🐢 SSE client too slow, disconnecting [remote=%s]", r.RemoteAddr)
+					return
+				}
+				if !filter.match(ev) {
+					continue
+				}
+				if err := write(toStreamEvent(listener, ev)); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
+// checkOrigin Origin 이 없거나(브라우저가 아닌 클라이언트) 같은 host 이거나 allowed 에 있으면 허용.
+// 인증된 브라우저 세션으로 다른 사이트가 WebSocket 을 여는 것을 막는다
+func checkOrigin(allowed []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+			return true
+		}
+		for _, o := range allowed {
+			if o == "*" || strings.EqualFold(o, origin) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// InvalidationWebSocketHandler invalidation 이벤트를 WebSocket JSON 메시지로 전달
+func InvalidationWebSocketHandler(listener *core.EventListener, cfg config.StreamConfig) http.HandlerFunc {
+	cfg = withStreamDefaults(cfg)
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+		CheckOrigin:     checkOrigin(cfg.AllowedOrigins),
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		filter, ok := newEventFilter(r)
+		if !ok {
+			writeForbidden(w, r)
+			return
+		}
+		from := lastEventID(r)
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer func(conn *websocket.Conn) {
+			err := conn.Close()
+			if err != nil {
+				// do nothing
+			}
+		}(conn)
+
+		// hijack 된 연결에 남은 서버 read timeout 을 해제. 쓰기는 메시지마다 deadline 을 건다
+		_ = conn.SetReadDeadline(time.Time{})
+
+		replay, complete, events, cancel := listener.WatchFrom(from, cfg.ClientBuffer)
+		defer cancel()
+
+		// 클라이언트 종료 감지용 reader
+		closed := make(chan struct{})
+		go func() {
+			defer close(closed)
+			for {
+				if _, _, err := conn.NextReader(); err != nil {
+					return
+				}
+			}
+		}()
+
+		write := func(ev streamEvent) error {
+			_ = conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+			return conn.WriteJSON(ev)
+		}
+
+		if !complete {
+			if err := write(resetEvent(listener, replay)); err != nil {
+				return
+			}
+			replay = nil
+		}
+		for _, ev := range replay {
+			if !filter.match(ev) {
+				continue
+			}
+			if err := write(toStreamEvent(listener, ev)); err != nil {
+				return
+			}
+		}
+
+		heartbeat := time.NewTicker(time.Duration(cfg.HeartbeatSeconds) * time.Second)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case <-closed:
+				return
+			case <-r.Context().Done():
+				_ = conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"),
+					time.Now().Add(streamWriteTimeout))
+				return
+			case <-heartbeat.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteTimeout)); err != nil {
+					return
+				}
+			case ev, ok := <-events:
+				if !ok {
+					logger.Logger.Warnf("🐢 WebSocket client too slow, disconnecting [remote=%s]", r.RemoteAddr)
+					_ = conn.WriteControl(websocket.CloseMessage,
+						websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too slow"),
+						time.Now().Add(streamWriteTimeout))
+					return
+				}
+				if !filter.match(ev) {
+					continue
+				}
+				if err := write(toStreamEvent(listener, ev)); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
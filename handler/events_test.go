@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"bufio"
+	"cache/auth"
+	"cache/config"
+	"cache/core"
+	"cache/core/cache_adapter"
+	"cache/core/strategy"
+	_interface "cache/interface"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestCheckOrigin(t *testing.T) {
+	check := checkOrigin([]string{"https://app.example.com"})
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"", true},
+		{"http://cache.internal:8000", true},
+		{"https://app.example.com", true},
+		{"https://evil.example.com", false},
+		{"null", false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "http://cache.internal:8000/v1/events/invalidations/ws", nil)
+		if tt.origin != "" {
+			r.Header.Set("Origin", tt.origin)
+		}
+		if got := check(r); got != tt.want {
+			t.Errorf("checkOrigin(%q) = %t, want %t", tt.origin, got, tt.want)
+		}
+	}
+}
+
+func TestEventFilterUsesCacheTopicACL(t *testing.T) {
+	guard := newTestGuard(t, config.ACLRule{Principal: "reader", Topics: []string{"users"}, Permissions: []string{"read"}})
+
+	var filter eventFilter
+	var allowed bool
+	h := guard.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		filter, allowed = newEventFilter(r)
+	}))
+	r := httptest.NewRequest(http.MethodGet, "/v1/events/invalidations", nil)
+	r.Header.Set("X-API-Key", "secret")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	if !allowed {
+		t.Fatal("request without explicit topics should be allowed")
+	}
+
+	if !filter.match(core.InvalidationEvent{Topic: "users", Key: "1"}) {
+		t.Error("event on a readable topic was filtered out")
+	}
+	if filter.match(core.InvalidationEvent{Topic: "payments", Key: "1"}) {
+		t.Error("event on a topic without read permission was delivered")
+	}
+}
+
+func TestEventFilterRejectsForbiddenTopicParam(t *testing.T) {
+	guard := newTestGuard(t, config.ACLRule{Principal: "reader", Topics: []string{"users"}, Permissions: []string{"read"}})
+
+	var allowed bool
+	h := guard.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, allowed = newEventFilter(r)
+	}))
+	r := httptest.NewRequest(http.MethodGet, "/v1/events/invalidations?topic=payments", nil)
+	r.Header.Set("X-API-Key", "secret")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	if allowed {
+		t.Fatal("explicit topic without read permission should be rejected")
+	}
+}
+
+func TestEventFilterPrefixAndTenant(t *testing.T) {
+	f := eventFilter{ctx: context.Background(), tenant: "a", topics: map[string]struct{}{"users": {}}, prefix: "42"}
+	tests := []struct {
+		ev   core.InvalidationEvent
+		want bool
+	}{
+		{core.InvalidationEvent{Tenant: "a", Topic: "users", Key: "42:x"}, true},
+		{core.InvalidationEvent{Tenant: "b", Topic: "users", Key: "42:x"}, false},
+		{core.InvalidationEvent{Tenant: "a", Topic: "orders", Key: "42:x"}, false},
+		{core.InvalidationEvent{Tenant: "a", Topic: "users", Key: "7"}, false},
+	}
+	for _, tt := range tests {
+		if got := f.match(tt.ev); got != tt.want {
+			t.Errorf("match(%+v) = %t, want %t", tt.ev, got, tt.want)
+		}
+	}
+}
+
+// newTestGuard API key "secret" 으로 principal "reader" 를 인증하는 Guard
+func newTestGuard(t *testing.T, acl ...config.ACLRule) *auth.Guard {
+	t.Helper()
+	guard, err := auth.NewGuard(context.Background(), config.AuthConfig{
+		Enabled: true,
+		APIKeys: []config.APIKeyConfig{{ID: "reader", Key: "secret"}},
+		ACL:     acl,
+		Audit:   config.AuditConfig{File: filepath.Join(t.TempDir(), "audit.log")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = guard.Close() })
+	return guard
+}
+
+// pushBroker Subscribe 로 받은 handler 를 보관해 테스트에서 메시지를 직접 전달
+type pushBroker struct {
+	stubBroker
+	handler _interface.MessageHandler
+}
+
+func (b *pushBroker) Subscribe(_ context.Context, h _interface.MessageHandler) error {
+	b.handler = h
+	return nil
+}
+
+func newStreamServer(t *testing.T, handler func(*core.EventListener, config.StreamConfig) http.HandlerFunc) (*httptest.Server, *pushBroker) {
+	t.Helper()
+	svc := core.NewCacheService(
+		cache_adapter.NewMemoryAdapter(config.MemoryConfig{}),
+		strategy.NewVersionedKeyStrategy(config.InvalidationConfig{}),
+	)
+	broker := &pushBroker{}
+	listener := core.NewEventListener(broker, svc)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	listener.Start(ctx)
+
+	srv := httptest.NewServer(handler(listener, config.StreamConfig{}))
+	t.Cleanup(srv.Close)
+	return srv, broker
+}
+
+// readSSE 다음 이벤트의 event 와 data 줄
+func readSSE(t *testing.T, r *bufio.Reader) (string, streamEvent) {
+	t.Helper()
+	var name string
+	var ev streamEvent
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &ev); err != nil {
+				t.Fatal(err)
+			}
+		case line == "" && name != "":
+			return name, ev
+		}
+	}
+}
+
+func TestInvalidationStreamDeliversFilteredEvents(t *testing.T) {
+	srv, broker := newStreamServer(t, InvalidationStreamHandler)
+
+	resp, err := http.Get(srv.URL + "?topic=users&prefix=42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	ctx := context.Background()
+	for _, msg := range []_interface.Message{
+		{Topic: "orders", Key: "42"},
+		{Topic: "users", Key: "7"},
+		{Topic: "users", Key: "42:profile"},
+	} {
+		if err := broker.handler(ctx, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	name, ev := readSSE(t, bufio.NewReader(resp.Body))
+	if name != "invalidate" || ev.Topic != "users" || ev.Key != "42:profile" || ev.ID == "" {
+		t.Fatalf("event = %s %+v", name, ev)
+	}
+}
+
+func TestInvalidationStreamResetsUnknownLastEventID(t *testing.T) {
+	srv, _ := newStreamServer(t, InvalidationStreamHandler)
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req.Header.Set("Last-Event-ID", "another-node-17")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if name, ev := readSSE(t, bufio.NewReader(resp.Body)); name != "reset" || ev.Type != "reset" {
+		t.Fatalf("first event = %s %+v, want reset", name, ev)
+	}
+}
+
+func TestInvalidationWebSocket(t *testing.T) {
+	srv, broker := newStreamServer(t, InvalidationWebSocketHandler)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// 구독이 등록될 때까지 메시지를 다시 보낸다
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(20 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			_ = broker.handler(context.Background(), _interface.Message{Topic: "users", Key: "1"})
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	var ev streamEvent
+	if err := conn.ReadJSON(&ev); err != nil {
+		t.Fatal(err)
+	}
+	if ev.Type != "invalidate" || ev.Topic != "users" || ev.Key != "1" {
+		t.Fatalf("event = %+v", ev)
+	}
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"cache/health"
+	"encoding/json"
+	"net/http"
+)
+
+// HealthzHandler liveness. 프로세스가 응답하면 항상 200, 의존성 상태는 참고용으로 포함
+func HealthzHandler(monitor *health.Monitor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeHealth(w, http.StatusOK, monitor.Report())
+	}
+}
+
+// ReadyzHandler readiness. critical 점검이 하나라도 down 이면 503
+func ReadyzHandler(monitor *health.Monitor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := monitor.Report()
+		status := http.StatusOK
+		if report.Status != health.StatusUp {
+			status = http.StatusServiceUnavailable
+		}
+		writeHealth(w, status, report)
+	}
+}
+
+func writeHealth(w http.ResponseWriter, status int, report health.Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		return
+	}
+}
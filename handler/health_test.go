@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"cache/config"
+	"cache/health"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReadyzReflectsCriticalChecks(t *testing.T) {
+	monitor := health.NewMonitor(config.HealthConfig{FailureThreshold: 1, IntervalSeconds: 3600})
+	var err error
+	monitor.Register("cache", true, func(context.Context) (map[string]interface{}, error) { return nil, err })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	err = errors.New("down")
+	monitor.Start(ctx)
+
+	for _, tt := range []struct {
+		handler http.HandlerFunc
+		want    int
+	}{
+		{ReadyzHandler(monitor), http.StatusServiceUnavailable},
+		{HealthzHandler(monitor), http.StatusOK},
+	} {
+		rec := httptest.NewRecorder()
+		tt.handler(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+		if rec.Code != tt.want {
+			t.Errorf("status = %d, want %d", rec.Code, tt.want)
+		}
+		var report health.Report
+		if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil || report.Checks["cache"].Status != health.StatusDown {
+			t.Errorf("body = %s", rec.Body)
+		}
+	}
+}
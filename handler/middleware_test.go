@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"cache/config"
+	"cache/requestid"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestAccessLog(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	access := &AccessLog{log: zap.New(core).Sugar()}
+
+	r := chi.NewRouter()
+	r.Use(requestid.Middleware, access.Middleware)
+	r.Get("/v1/cache/{topic}/{key}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("hello"))
+	})
+	r.Get("/boom", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/cache/users/1", nil)
+	req.Header.Set(requestid.Header, "req-42")
+	req.Header.Set("User-Agent", "test-agent")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/boom", nil))
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("entries = %d, want 2", len(entries))
+	}
+
+	ok := entries[0]
+	fields := ok.ContextMap()
+	want := map[string]interface{}{
+		"method":     "GET",
+		"path":       "/v1/cache/users/1",
+		"route":      "/v1/cache/{topic}/{key}",
+		"status":     int64(http.StatusOK),
+		"bytes":      int64(5),
+		"request_id": "req-42",
+		"user_agent": "test-agent",
+	}
+	for k, v := range want {
+		if fields[k] != v {
+			t.Errorf("%s = %v (%T), want %v", k, fields[k], fields[k], v)
+		}
+	}
+	if ok.Level != zapcore.InfoLevel {
+		t.Errorf("level = %s, want info", ok.Level)
+	}
+	if _, has := fields["duration_ms"]; !has {
+		t.Error("missing duration_ms")
+	}
+
+	// 5xx 는 error 레벨
+	if entries[1].Level != zapcore.ErrorLevel || entries[1].ContextMap()["status"] != int64(http.StatusBadGateway) {
+		t.Fatalf("5xx entry = %v %v", entries[1].Level, entries[1].ContextMap())
+	}
+}
+
+func TestAccessLogDisabled(t *testing.T) {
+	a := NewAccessLog(config.LoggingConfig{})
+	if a != nil {
+		t.Fatal("disabled access log should be nil")
+	}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	w := httptest.NewRecorder()
+	a.Middleware(next).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d", w.Code)
+	}
+}
@@ -0,0 +1,17 @@
+package handler
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// OpenAPISpec /v1 API 의 OpenAPI 3 스펙. route 를 바꾸면 함께 수정하고 go test ./handler 로 확인한다
+//
+//go:embed openapi.yaml
+var OpenAPISpec []byte
+
+// OpenAPIHandler 스펙 원문 제공
+func OpenAPIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(OpenAPISpec)
+}
@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"cache/config"
+	"cache/core"
+	"cache/core/cache_adapter"
+	"cache/core/strategy"
+	"cache/health"
+	_interface "cache/interface"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"gopkg.in/yaml.v3"
+)
+
+// TestOpenAPIMatchesRouter handler/openapi.yaml 과 실제 router 가 일치하는지 확인하는 contract 검사
+//
+// 1. 스펙의 path/method 와 router 에 등록된 route 가 양방향으로 일치하는지
+// 2. path template 의 변수와 스펙의 path parameter 가 일치하는지
+// 3. 스트림을 제외한 각 operation 을 실제로 호출해 응답 status 와 Content-Type 이 스펙에 있는지
+func TestOpenAPIMatchesRouter(t *testing.T) {
+	var s spec
+	if err := yaml.Unmarshal(OpenAPISpec, &s); err != nil {
+		t.Fatalf("parse openapi.yaml: %v", err)
+	}
+
+	router := newSpecRouter()
+	t.Run("routes", func(t *testing.T) { checkRoutes(t, s, router) })
+	t.Run("parameters", func(t *testing.T) { checkParameters(t, s) })
+	t.Run("responses", func(t *testing.T) { checkResponses(t, s, router) })
+}
+
+type spec struct {
+	Paths      map[string]pathItem `yaml:"paths"`
+	Components struct {
+		Parameters  map[string]parameter   `yaml:"parameters"`
+		Responses   map[string]response    `yaml:"responses"`
+		RequestBody map[string]interface{} `yaml:"requestBodies"`
+	} `yaml:"components"`
+}
+
+type pathItem struct {
+	Parameters []parameter           `yaml:"parameters"`
+	Operations map[string]*operation `yaml:",inline"`
+}
+
+type operation struct {
+	OperationID string              `yaml:"operationId"`
+	Parameters  []parameter         `yaml:"parameters"`
+	RequestBody interface{}         `yaml:"requestBody"`
+	Responses   map[string]response `yaml:"responses"`
+}
+
+type parameter struct {
+	Ref      string `yaml:"$ref"`
+	Name     string `yaml:"name"`
+	In       string `yaml:"in"`
+	Required bool   `yaml:"required"`
+}
+
+type response struct {
+	Ref     string                 `yaml:"$ref"`
+	Content map[string]interface{} `yaml:"content"`
+}
+
+var templateVar = regexp.MustCompile(`\{([^}]+)\}`)
+
+// newRouter 외부 의존성 없이 모든 route 가 등록되도록 memory cache 와 stub broker 사용
+func newSpecRouter() *chi.Mux {
+	svc := core.NewCacheService(
+		cache_adapter.NewMemoryAdapter(config.MemoryConfig{}),
+		strategy.NewVersionedKeyStrategy(config.InvalidationConfig{}),
+	)
+	broker := stubBroker{}
+	listener := core.NewEventListener(broker, svc)
+	monitor := health.NewMonitor(config.HealthConfig{})
+	return NewRouter(svc, broker, listener, config.StreamConfig{}, monitor, nil, nil, nil, nil).(*chi.Mux)
+}
+
+func checkRoutes(t *testing.T, s spec, router chi.Routes) {
+	documented := make(map[string]bool)
+	for path, item := range s.Paths {
+		for method := range item.Operations {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	registered := make(map[string]bool)
+	_ = chi.Walk(router, func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		route = strings.TrimSuffix(route, "/*")
+		if len(route) > 1 {
+			route = strings.TrimSuffix(route, "/")
+		}
+		op := method + " " + route
+		registered[op] = true
+		if !documented[op] {
+			t.Errorf("route not in spec: %s", op)
+		}
+		return nil
+	})
+	for op := range documented {
+		if !registered[op] {
+			t.Errorf("spec operation has no route: %s", op)
+		}
+	}
+}
+
+func checkParameters(t *testing.T, s spec) {
+	for path, item := range s.Paths {
+		want := make(map[string]bool)
+		for _, m := range templateVar.FindAllStringSubmatch(path, -1) {
+			want[m[1]] = true
+		}
+		for method, op := range item.Operations {
+			got := make(map[string]bool)
+			for _, p := range append(append([]parameter(nil), item.Parameters...), op.Parameters...) {
+				p = s.resolveParameter(p)
+				if p.In != "path" {
+					continue
+				}
+				if !p.Required {
+					t.Errorf("%s %s: path parameter %q must be required", method, path, p.Name)
+				}
+				got[p.Name] = true
+			}
+			for name := range want {
+				if !got[name] {
+					t.Errorf("%s %s: missing path parameter %q", method, path, name)
+				}
+			}
+			for name := range got {
+				if !want[name] {
+					t.Errorf("%s %s: path parameter %q is not in the template", method, path, name)
+				}
+			}
+		}
+	}
+}
+
+// checkResponses 스트림이 아닌 operation 을 호출해 실제 응답이 스펙에 문서화되어 있는지 확인
+func checkResponses(t *testing.T, s spec, router http.Handler) {
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	for path, item := range s.Paths {
+		for method, op := range item.Operations {
+			if isStream(s, op) {
+				continue
+			}
+			req, err := http.NewRequest(strings.ToUpper(method), srv.URL+s.exampleURL(path, item, op), exampleBody(op))
+			if err != nil {
+				t.Errorf("%s %s: %v", method, path, err)
+				continue
+			}
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Errorf("%s %s: %v", method, path, err)
+				continue
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+
+			documented, ok := op.Responses[strconv.Itoa(resp.StatusCode)]
+			if !ok {
+				t.Errorf("%s %s: undocumented status %d", method, path, resp.StatusCode)
+				continue
+			}
+			if ct := contentType(resp); ct != "" {
+				if _, ok := s.resolveResponse(documented).Content[ct]; !ok {
+					t.Errorf("%s %s: status %d content type %q not in spec", method, path, resp.StatusCode, ct)
+				}
+			}
+		}
+	}
+}
+
+func (s spec) resolveParameter(p parameter) parameter {
+	if p.Ref == "" {
+		return p
+	}
+	return s.Components.Parameters[strings.TrimPrefix(p.Ref, "#/components/parameters/")]
+}
+
+func (s spec) resolveResponse(r response) response {
+	if r.Ref == "" {
+		return r
+	}
+	return s.Components.Responses[strings.TrimPrefix(r.Ref, "#/components/responses/")]
+}
+
+// exampleURL path 변수와 필수 쿼리 파라미터를 예시 값으로 채운다
+func (s spec) exampleURL(path string, item pathItem, op *operation) string {
+	url := templateVar.ReplaceAllStringFunc(path, func(v string) string {
+		switch v {
+		case "{partition}", "{offset}":
+			return "0"
+		default:
+			return "spec"
+		}
+	})
+	var query []string
+	for _, p := range append(append([]parameter(nil), item.Parameters...), op.Parameters...) {
+		if p = s.resolveParameter(p); p.In == "query" && p.Required {
+			query = append(query, p.Name+"=spec")
+		}
+	}
+	if len(query) > 0 {
+		url += "?" + strings.Join(query, "&")
+	}
+	return url
+}
+
+func exampleBody(op *operation) io.Reader {
+	if op.RequestBody == nil {
+		return nil
+	}
+	if strings.HasPrefix(op.OperationID, "batch") {
+		return strings.NewReader(`{"items":[{"topic":"spec","key":"spec","value":"v"}]}`)
+	}
+	return strings.NewReader(`{"value":"v","ttl":1}`)
+}
+
+func isStream(s spec, op *operation) bool {
+	if _, ok := op.Responses["101"]; ok {
+		return true
+	}
+	if ok, found := op.Responses["200"]; found {
+		_, sse := s.resolveResponse(ok).Content["text/event-stream"]
+		return sse
+	}
+	return false
+}
+
+func contentType(resp *http.Response) string {
+	ct := resp.Header.Get("Content-Type")
+	if i := strings.Index(ct, ";"); i >= 0 {
+		ct = ct[:i]
+	}
+	return strings.TrimSpace(ct)
+}
+
+// stubBroker DLQ route 까지 등록되도록 IDeadLetterQueue 를 함께 구현
+type stubBroker struct{}
+
+func (stubBroker) Publish(context.Context, string, string) error              { return nil }
+func (stubBroker) Subscribe(context.Context, _interface.MessageHandler) error { return nil }
+func (stubBroker) Ping(context.Context) error                                 { return nil }
+func (stubBroker) ConsumerStatus() _interface.ConsumerStatus                  { return _interface.ConsumerStatus{} }
+func (stubBroker) Close() error                                               { return nil }
+
+func (stubBroker) DeadLetters(context.Context, int) ([]_interface.DeadLetter, error) {
+	return nil, nil
+}
+
+func (stubBroker) ReplayDeadLetter(context.Context, int, int64) error {
+	return nil
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"cache/apierror"
+	"cache/auth"
+	"cache/config"
+	"cache/infrautil"
+	"cache/metrics"
+	"cache/tenant"
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimiter set/invalidate 요청을 클라이언트와 topic 단위 token bucket 으로 제한
+type RateLimiter struct {
+	clients *infrautil.RateLimiter
+	topic   *infrautil.RateLimiter
+	topics  map[string]*infrautil.RateLimiter // topic 별 재정의
+}
+
+// NewRateLimiter 비활성화 설정이면 nil 을 반환하며, nil 은 모든 요청을 허용한다
+func NewRateLimiter(cfg config.RateLimitConfig) *RateLimiter {
+	if !cfg.Enabled {
+		return nil
+	}
+	l := &RateLimiter{
+		clients: infrautil.NewRateLimiter(cfg.PerClient.RequestsPerSecond, cfg.PerClient.Burst),
+		topic:   infrautil.NewRateLimiter(cfg.PerTopic.RequestsPerSecond, cfg.PerTopic.Burst),
+		topics:  make(map[string]*infrautil.RateLimiter, len(cfg.Topics)),
+	}
+	for t, c := range cfg.Topics {
+		// viper 가 map key 를 소문자로 바꾸므로 대소문자 구분 없이 찾는다
+		l.topics[strings.ToLower(t)] = infrautil.NewRateLimiter(c.RequestsPerSecond, c.Burst)
+	}
+	return l
+}
+
+// allowClient 요청 클라이언트의 bucket 에서 n 개 token 사용
+func (l *RateLimiter) allowClient(r *http.Request, n int) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	return l.clients.AllowN(clientID(r.Context(), r.RemoteAddr), n)
+}
+
+// allowTopic topic bucket 은 tenant 별로 따로 둔다
+func (l *RateLimiter) allowTopic(r *http.Request, topic string) (bool, time.Duration) {
+	return l.allowTopicIn(r.Context(), topic)
+}
+
+func (l *RateLimiter) allowTopicIn(ctx context.Context, topic string) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	bucket := tenant.ID(ctx) + "/" + topic
+	if tl, ok := l.topics[strings.ToLower(topic)]; ok {
+		return tl.AllowN(bucket, 1)
+	}
+	return l.topic.AllowN(bucket, 1)
+}
+
+// returnClient topic 제한에 걸려 처리되지 않은 요청의 client token 을 되돌린다
+func (l *RateLimiter) returnClient(ctx context.Context, remoteAddr string, n int) {
+	if l == nil {
+		return
+	}
+	l.clients.ReturnN(clientID(ctx, remoteAddr), n)
+}
+
+// allow 단건 요청용. 제한에 걸리면 429 로 응답하고 false
+func (l *RateLimiter) allow(w http.ResponseWriter, r *http.Request, topic string) bool {
+	if ok, wait := l.allowClient(r, 1); !ok {
+		metrics.Rejections.WithLabelValues("client_rate", metrics.Topic(topic)).Inc()
+		writeTooManyRequests(w, r, wait, apierror.CodeRateLimited, "rate limit exceeded")
+		return false
+	}
+	if ok, wait := l.allowTopic(r, topic); !ok {
+		l.returnClient(r.Context(), r.RemoteAddr, 1)
+		metrics.Rejections.WithLabelValues("topic_rate", metrics.Topic(topic)).Inc()
+		writeTooManyRequests(w, r, wait, apierror.CodeRateLimited, "topic rate limit exceeded")
+		return false
+	}
+	return true
+}
+
+// allowBatch 배치 전체를 클라이언트 bucket 에서 항목 수만큼 차감. topic 제한은 항목별로 allowTopic 사용
+func (l *RateLimiter) allowBatch(w http.ResponseWriter, r *http.Request, n int) bool {
+	if ok, wait := l.allowClient(r, n); !ok {
+		metrics.Rejections.WithLabelValues("client_rate", "").Inc()
+		writeTooManyRequests(w, r, wait, apierror.CodeRateLimited, "rate limit exceeded")
+		return false
+	}
+	return true
+}
+
+// allowItem 배치 항목의 topic 제한. 걸리면 allowBatch 에서 차감한 항목분 token 을 되돌리고 항목 오류 메시지를 반환
+func (l *RateLimiter) allowItem(r *http.Request, topic string) string {
+	if ok, _ := l.allowTopic(r, topic); !ok {
+		l.returnClient(r.Context(), r.RemoteAddr, 1)
+		metrics.Rejections.WithLabelValues("topic_rate", metrics.Topic(topic)).Inc()
+		return "rate limited"
+	}
+	return ""
+}
+
+// AllowClient http.Request 없이 들어온 호출(gRPC)용. remoteAddr 는 인증되지 않은 호출을 구분하는 peer 주소
+func (l *RateLimiter) AllowClient(ctx context.Context, remoteAddr string, topic string, n int) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	ok, wait := l.clients.AllowN(clientID(ctx, remoteAddr), n)
+	if !ok {
+		metrics.Rejections.WithLabelValues("client_rate", metrics.Topic(topic)).Inc()
+	}
+	return ok, wait
+}
+
+// AllowTopic http.Request 없이 들어온 호출(gRPC)용 topic 제한. 걸리면 AllowClient 로 차감한 token 1 개를 되돌린다
+func (l *RateLimiter) AllowTopic(ctx context.Context, remoteAddr string, topic string) (bool, time.Duration) {
+	ok, wait := l.allowTopicIn(ctx, topic)
+	if !ok {
+		l.returnClient(ctx, remoteAddr, 1)
+		metrics.Rejections.WithLabelValues("topic_rate", metrics.Topic(topic)).Inc()
+	}
+	return ok, wait
+}
+
+// clientID 인증된 principal, 없으면 client IP
+func clientID(ctx context.Context, remoteAddr string) string {
+	if p, ok := auth.PrincipalFrom(ctx); ok {
+		return "principal:" + p.Subject
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	return "ip:" + host
+}
+
+// writeTooManyRequests 429 와 함께 다시 시도할 시각을 Retry-After 로 알린다
+func writeTooManyRequests(w http.ResponseWriter, r *http.Request, wait time.Duration, code, msg string) {
+	secs := int(math.Ceil(wait.Seconds()))
+	if secs < 1 {
+		secs = 1
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(secs))
+	apierror.Write(w, r, http.StatusTooManyRequests, code, msg)
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"cache/config"
+	"cache/core"
+	"cache/core/cache_adapter"
+	"cache/core/strategy"
+	"cache/health"
+	"cache/metrics"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newLimitedRouter(cfg config.RateLimitConfig, quota config.QuotaConfig) http.Handler {
+	svc := core.NewCacheService(
+		cache_adapter.NewMemoryAdapter(config.MemoryConfig{}),
+		strategy.NewVersionedKeyStrategy(config.InvalidationConfig{}),
+	)
+	svc.SetQuota(quota)
+	broker := stubBroker{}
+	listener := core.NewEventListener(broker, svc)
+	monitor := health.NewMonitor(config.HealthConfig{})
+	return NewRouter(svc, broker, listener, config.StreamConfig{}, monitor, nil, NewRateLimiter(cfg), nil, nil)
+}
+
+func serve(h http.Handler, method, path, body, remote string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	if remote != "" {
+		r.RemoteAddr = remote
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestClientRateLimit(t *testing.T) {
+	h := newLimitedRouter(config.RateLimitConfig{
+		Enabled:   true,
+		PerClient: config.LimitConfig{RequestsPerSecond: 0.5, Burst: 2},
+	}, config.QuotaConfig{})
+	before := testutil.ToFloat64(metrics.Rejections.WithLabelValues("client_rate", metrics.OtherTopic))
+
+	for i := 0; i < 2; i++ {
+		if w := serve(h, http.MethodDelete, "/v1/cache/users/1", "", "10.0.0.1:1234"); w.Code != http.StatusNoContent {
+			t.Fatalf("request %d: status = %d", i, w.Code)
+		}
+	}
+	w := serve(h, http.MethodDelete, "/v1/cache/users/1", "", "10.0.0.1:5678")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "2" {
+		t.Fatalf("Retry-After = %q, want 2", got)
+	}
+	if got := testutil.ToFloat64(metrics.Rejections.WithLabelValues("client_rate", metrics.OtherTopic)) - before; got != 1 {
+		t.Fatalf("client_rate rejections = %v, want 1", got)
+	}
+
+	// 다른 IP 는 별도 bucket, 읽기는 제한하지 않는다
+	if w := serve(h, http.MethodDelete, "/v1/cache/users/1", "", "10.0.0.2:1234"); w.Code != http.StatusNoContent {
+		t.Fatalf("other client: status = %d", w.Code)
+	}
+	if w := serve(h, http.MethodGet, "/v1/cache/users/1", "", "10.0.0.1:1234"); w.Code == http.StatusTooManyRequests {
+		t.Fatal("read was rate limited")
+	}
+}
+
+func TestTopicRateLimit(t *testing.T) {
+	h := newLimitedRouter(config.RateLimitConfig{
+		Enabled:  true,
+		PerTopic: config.LimitConfig{RequestsPerSecond: 1, Burst: 1},
+		Topics:   map[string]config.LimitConfig{"hot": {RequestsPerSecond: 1, Burst: 3}},
+	}, config.QuotaConfig{})
+
+	tests := []struct {
+		topic   string
+		allowed int
+	}{
+		{"users", 1},
+		{"hot", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			path := "/v1/cache/" + tt.topic + "/1"
+			for i := 0; i < tt.allowed; i++ {
+				if w := serve(h, http.MethodPut, path, `{"value":"v"}`, ""); w.Code != http.StatusNoContent {
+					t.Fatalf("request %d: status = %d", i, w.Code)
+				}
+			}
+			if w := serve(h, http.MethodPut, path, `{"value":"v"}`, ""); w.Code != http.StatusTooManyRequests {
+				t.Fatalf("status = %d, want 429", w.Code)
+			}
+		})
+	}
+}
+
+func TestTopicRejectionKeepsClientTokens(t *testing.T) {
+	h := newLimitedRouter(config.RateLimitConfig{
+		Enabled:   true,
+		PerClient: config.LimitConfig{RequestsPerSecond: 0.1, Burst: 2},
+		PerTopic:  config.LimitConfig{RequestsPerSecond: 0.1, Burst: 1},
+	}, config.QuotaConfig{})
+
+	if w := serve(h, http.MethodPut, "/v1/cache/hot/1", `{"value":"v"}`, ""); w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d", w.Code)
+	}
+	// topic 에서 거절된 요청은 client token 을 쓰지 않는다
+	for i := 0; i < 3; i++ {
+		if w := serve(h, http.MethodPut, "/v1/cache/hot/1", `{"value":"v"}`, ""); w.Code != http.StatusTooManyRequests {
+			t.Fatalf("throttled request %d: status = %d, want 429", i, w.Code)
+		}
+	}
+	if w := serve(h, http.MethodPut, "/v1/cache/cold/1", `{"value":"v"}`, ""); w.Code != http.StatusNoContent {
+		t.Fatalf("other topic: status = %d, want 204", w.Code)
+	}
+}
+
+func TestBatchRateLimit(t *testing.T) {
+	h := newLimitedRouter(config.RateLimitConfig{
+		Enabled:   true,
+		PerClient: config.LimitConfig{RequestsPerSecond: 1, Burst: 3},
+		PerTopic:  config.LimitConfig{RequestsPerSecond: 1, Burst: 1},
+	}, config.QuotaConfig{})
+
+	// topic 제한은 항목별 오류로 알린다
+	w := serve(h, http.MethodPost, "/v1/batch/set", `{"items":[{"topic":"users","key":"1","value":"a"},{"topic":"users","key":"2","value":"b"}]}`, "")
+	var resp struct {
+		Items []batchResult `json:"items"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Items) != 2 || resp.Items[0].Error != "" || resp.Items[1].Error != "rate limited" {
+		t.Fatalf("items = %+v", resp.Items)
+	}
+
+	// 클라이언트 bucket 은 처리된 항목 수만큼만 차감되어 남은 2개로는 3개짜리 배치가 막힌다
+	w = serve(h, http.MethodPost, "/v1/batch/invalidate", `{"items":[{"topic":"a","key":"1"},{"topic":"b","key":"1"},{"topic":"c","key":"1"}]}`, "")
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") == "" {
+		t.Fatalf("status = %d, Retry-After = %q", w.Code, w.Header().Get("Retry-After"))
+	}
+}
+
+func TestQuotaReturnsTooManyRequests(t *testing.T) {
+	h := newLimitedRouter(config.RateLimitConfig{}, config.QuotaConfig{
+		Topics: map[string]config.TopicQuota{"users": {MaxKeys: 1}},
+	})
+
+	if w := serve(h, http.MethodPut, "/v1/cache/users/1", `{"value":"v","ttl":30}`, ""); w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	w := serve(h, http.MethodPut, "/v1/cache/users/2", `{"value":"v"}`, "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want 429", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "30" {
+		t.Fatalf("Retry-After = %q, want 30", got)
+	}
+}
+
+func TestNilRateLimiterAllows(t *testing.T) {
+	if NewRateLimiter(config.RateLimitConfig{}) != nil {
+		t.Fatal("disabled config should return nil")
+	}
+	var l *RateLimiter
+	if ok, _ := l.AllowClient(httptest.NewRequest(http.MethodGet, "/", nil).Context(), "10.0.0.1:1", "users", 100); !ok {
+		t.Fatal("nil limiter rejected")
+	}
+	if ok, _ := l.AllowTopic(httptest.NewRequest(http.MethodGet, "/", nil).Context(), "10.0.0.1:1", "users"); !ok {
+		t.Fatal("nil limiter rejected")
+	}
+}
@@ -1,19 +1,124 @@
 package handler
 
 import (
+	"cache/apierror"
+	"cache/auth"
+	"cache/config"
 	"cache/core"
+	"cache/core/event_broker"
+	"cache/health"
 	"cache/interface"
+	"cache/metrics"
+	"cache/requestid"
+	"cache/tenant"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 )
 
-func NewRouter(cacheService *core.CacheService, broker _interface.IEventBroker) http.Handler {
+// NewRouter API 는 /v1 아래에 두고 스펙은 /v1/openapi.yaml 로 제공한다 (handler/openapi.yaml).
+// guard 가 nil 이면 인증 없이, limits 가 nil 이면 속도 제한 없이 모든 요청을 허용한다.
+// tenants 가 nil 이면 tenant 구분 없이, access 가 nil 이면 access log 없이 동작한다.
+// /metrics, /healthz, /readyz 와 스펙은 인증 대상에서 제외
+func NewRouter(cacheService *core.CacheService, broker _interface.IEventBroker, listener *core.EventListener, streamCfg config.StreamConfig, monitor *health.Monitor, guard *auth.Guard, limits *RateLimiter, tenants *tenant.Resolver, access *AccessLog) http.Handler {
 	r := chi.NewRouter()
+	r.Use(requestid.Middleware)
+	r.Use(access.Middleware)
+	r.Use(MetricsMiddleware)
+	r.Use(TracingMiddleware)
 
-	r.Get("/cache/{topic}/{key}", GetCacheHandler(cacheService))
-	r.Post("/cache/{topic}/{key}", SetCacheHandler(cacheService))
-	r.Post("/invalidate/{topic}/{key}", InvalidateHandler(cacheService, broker))
+	// 경로 방식과 쿼리 방식(?topic=&key=)은 같은 handler 를 쓴다
+	get := GetCacheHandler(cacheService)
+	set := SetCacheHandler(cacheService, limits)
+	invalidate := InvalidateHandler(cacheService, broker, limits)
+
+	// protected 인증과 tenant 결정을 거치는 route. /v1 과 이전 경로가 함께 쓴다
+	protected := func(r chi.Router) {
+		if guard != nil {
+			r.Use(guard.Middleware)
+		}
+		// tenant 는 인증된 principal 을 보고 결정하므로 인증 다음에 둔다
+		r.Use(tenants.Middleware)
+
+		r.Post("/batch/get", BatchGetHandler(cacheService))
+		r.Post("/batch/set", BatchSetHandler(cacheService, limits))
+		r.Post("/batch/invalidate", BatchInvalidateHandler(cacheService, broker, limits))
+
+		r.Get("/events/invalidations", InvalidationStreamHandler(listener, streamCfg))
+		r.Get("/events/invalidations/ws", InvalidationWebSocketHandler(listener, streamCfg))
+
+		if dlq, ok := event_broker.AsDeadLetterQueue(broker); ok {
+			r.Group(func(r chi.Router) {
+				if guard != nil {
+					r.Use(guard.Require(auth.PermAdmin))
+				}
+				r.Get("/admin/dlq", DeadLetterListHandler(dlq))
+				r.Post("/admin/dlq/{partition}/{offset}/replay", DeadLetterReplayHandler(dlq))
+			})
+		}
+	}
+
+	r.Route("/v1", func(r chi.Router) {
+		r.Get("/openapi.yaml", OpenAPIHandler)
+
+		r.Group(func(r chi.Router) {
+			protected(r)
+			r.Get("/cache/{topic}/{key}", get)
+			r.Put("/cache/{topic}/{key}", set)
+			r.Delete("/cache/{topic}/{key}", invalidate)
+			r.Get("/cache", get)
+			r.Put("/cache", set)
+			r.Delete("/cache", invalidate)
+		})
+	})
+
+	// /v1 이전의 경로. 같은 handler 로 처리하되 성공 응답은 이전 형태(본문 그대로의 값, 200)를 유지하고
+	// Deprecation 헤더로 /v1 경로를 알린다
+	r.Group(func(r chi.Router) {
+		r.Use(deprecatedRoute)
+		protected(r)
+		r.Get("/cache/{topic}/{key}", LegacyGetCacheHandler(cacheService))
+		r.Post("/cache/{topic}/{key}", legacyStatus(set))
+		r.Post("/invalidate/{topic}/{key}", legacyStatus(invalidate))
+	})
+
+	r.Method(http.MethodGet, "/metrics", metrics.Handler())
+	r.Get("/healthz", HealthzHandler(monitor))
+	r.Get("/readyz", ReadyzHandler(monitor))
+	r.NotFound(apierror.NotFound)
+	r.MethodNotAllowed(apierror.MethodNotAllowed)
 
 	return r
 }
+
+// deprecatedRoute 이전 경로 응답에 Deprecation 과 대체 경로(Link successor-version)를 싣는다
+func deprecatedRoute(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		successor := "/v1" + r.URL.EscapedPath()
+		if rest, ok := strings.CutPrefix(r.URL.EscapedPath(), "/invalidate/"); ok {
+			successor = "/v1/cache/" + rest
+		}
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", "<"+successor+`>; rel="successor-version"`)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// legacyStatus /v1 의 204 No Content 를 이전 경로가 돌려주던 200 OK 로 바꾼다
+func legacyStatus(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		next(legacyStatusWriter{w}, r)
+	}
+}
+
+type legacyStatusWriter struct {
+	http.ResponseWriter
+}
+
+func (w legacyStatusWriter) WriteHeader(code int) {
+	if code == http.StatusNoContent {
+		code = http.StatusOK
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
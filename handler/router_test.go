@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDeprecatedRoutes(t *testing.T) {
+	router := newSpecRouter()
+
+	tests := []struct {
+		method    string
+		path      string
+		body      string
+		wantCode  int
+		wantBody  string
+		successor string
+	}{
+		// 이전 경로의 성공 응답 형태(200, 본문 그대로의 값)를 유지한다
+		{http.MethodPost, "/cache/users/1", `{"value":"v"}`, http.StatusOK, "", "/v1/cache/users/1"},
+		{http.MethodGet, "/cache/users/1", "", http.StatusOK, "v", "/v1/cache/users/1"},
+		{http.MethodPost, "/invalidate/users/1", "", http.StatusOK, "", "/v1/cache/users/1"},
+		{http.MethodPost, "/batch/get", `{"items":[]}`, http.StatusOK, "", "/v1/batch/get"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Fatalf("body = %q, want %q", w.Body, tt.wantBody)
+			}
+			if got := w.Header().Get("Deprecation"); got != "true" {
+				t.Fatalf("Deprecation = %q, want true", got)
+			}
+			if want := "<" + tt.successor + `>; rel="successor-version"`; w.Header().Get("Link") != want {
+				t.Fatalf("Link = %q, want %q", w.Header().Get("Link"), want)
+			}
+		})
+	}
+}
+
+func TestVersionedRoutesAreNotDeprecated(t *testing.T) {
+	w := httptest.NewRecorder()
+	newSpecRouter().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/cache/users/1", nil))
+
+	if got := w.Header().Get("Deprecation"); got != "" {
+		t.Fatalf("Deprecation = %q, want none", got)
+	}
+}
@@ -0,0 +1,74 @@
+package health
+
+import (
+	"cache/core"
+	_interface "cache/interface"
+	"context"
+	"errors"
+)
+
+// CacheCheck cache backend ping
+func CacheCheck(adapter _interface.ICacheAdapter) CheckFunc {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		var details map[string]interface{}
+		if b, ok := adapter.(interface{ BreakerState() string }); ok {
+			details = map[string]interface{}{"breaker": b.BreakerState()}
+		}
+		return details, adapter.Ping(ctx)
+	}
+}
+
+// BrokerCheck event broker 연결
+func BrokerCheck(broker _interface.IEventBroker) CheckFunc {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		return nil, broker.Ping(ctx)
+	}
+}
+
+// GroupCheck consumer group 참여 여부
+func GroupCheck(broker _interface.IEventBroker) CheckFunc {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		status := broker.ConsumerStatus()
+		details := map[string]interface{}{
+			"group_id":  status.GroupID,
+			"member_id": status.MemberID,
+			"joined":    status.Joined,
+		}
+		if !status.Joined {
+			if status.Error != "" {
+				return details, errors.New(status.Error)
+			}
+			return details, errors.New("not a member of the consumer group")
+		}
+		return details, nil
+	}
+}
+
+// ListenerCheck invalidation listener 동작 여부
+func ListenerCheck(listener *core.EventListener) CheckFunc {
+	return func(ctx context.Context) (map[string]interface{}, error) {
+		status := listener.Status()
+		details := map[string]interface{}{
+			"running":   status.Running,
+			"processed": status.Processed,
+		}
+		if !status.LastEventAt.IsZero() {
+			details["last_event_at"] = status.LastEventAt
+		}
+		if !status.Running {
+			if status.Error != "" {
+				return details, errors.New(status.Error)
+			}
+			return details, errors.New("listener not running")
+		}
+		if status.Replaying {
+			details["replaying"] = true
+			return details, errors.New("replaying missed invalidations")
+		}
+		if status.ReadFailures > 0 {
+			details["read_failures"] = status.ReadFailures
+			return details, errors.New(status.ReadError)
+		}
+		return details, nil
+	}
+}
@@ -0,0 +1,171 @@
+package health
+
+import (
+	"cache/config"
+	"cache/logger"
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	StatusUp      = "up"
+	StatusDown    = "down"
+	StatusUnknown = "unknown"
+)
+
+// CheckFunc 점검 결과. details 는 응답 JSON 에 그대로 포함된다
+type CheckFunc func(ctx context.Context) (details map[string]interface{}, err error)
+
+type CheckResult struct {
+	Status              string                 `json:"status"`
+	Critical            bool                   `json:"critical"`
+	ConsecutiveFailures int                    `json:"consecutive_failures"`
+	LastError           string                 `json:"last_error,omitempty"`
+	LastChecked         time.Time              `json:"last_checked"`
+	LastSuccess         time.Time              `json:"last_success,omitempty"`
+	Details             map[string]interface{} `json:"details,omitempty"`
+}
+
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+type check struct {
+	name     string
+	critical bool
+	fn       CheckFunc
+}
+
+// Monitor 등록된 점검을 주기적으로 실행한다.
+// 점검이 failure_threshold 회 연속 실패해야 down 으로 판단해 일시적인 장애로 readiness 가 흔들리지 않게 한다.
+type Monitor struct {
+	interval  time.Duration
+	timeout   time.Duration
+	threshold int
+	log       *zap.SugaredLogger
+
+	mu      sync.RWMutex
+	checks  []check
+	results map[string]CheckResult
+}
+
+func NewMonitor(cfg config.HealthConfig) *Monitor {
+	m := &Monitor{
+		interval:  time.Duration(cfg.IntervalSeconds) * time.Second,
+		timeout:   time.Duration(cfg.TimeoutMs) * time.Millisecond,
+		threshold: cfg.FailureThreshold,
+		log:       logger.Logger,
+		results:   make(map[string]CheckResult),
+	}
+	if m.interval <= 0 {
+		m.interval = 5 * time.Second
+	}
+	if m.timeout <= 0 {
+		m.timeout = 2 * time.Second
+	}
+	if m.threshold <= 0 {
+		m.threshold = 3
+	}
+	return m
+}
+
+// Register 점검 등록. critical 점검이 down 이면 readiness 가 false 가 된다
+func (m *Monitor) Register(name string, critical bool, fn CheckFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.checks = append(m.checks, check{name: name, critical: critical, fn: fn})
+	m.results[name] = CheckResult{Status: StatusUnknown, Critical: critical}
+}
+
+// Start 즉시 한 번 점검 후 ctx 가 끝날 때까지 주기적으로 점검
+func (m *Monitor) Start(ctx context.Context) {
+	m.runAll(ctx)
+	go func() {
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.runAll(ctx)
+			}
+		}
+	}()
+}
+
+func (m *Monitor) runAll(ctx context.Context) {
+	m.mu.RLock()
+	checks := append([]check(nil), m.checks...)
+	m.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, c := range checks {
+		wg.Add(1)
+		go func(c check) {
+			defer wg.Done()
+			m.run(ctx, c)
+		}(c)
+	}
+	wg.Wait()
+}
+
+func (m *Monitor) run(ctx context.Context, c check) {
+	checkCtx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+	details, err := c.fn(checkCtx)
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	prev := m.results[c.name]
+	res := CheckResult{
+		Status:      prev.Status,
+		Critical:    c.critical,
+		LastChecked: now,
+		LastSuccess: prev.LastSuccess,
+		Details:     details,
+	}
+	if err == nil {
+		res.Status = StatusUp
+		res.LastSuccess = now
+		if prev.Status == StatusDown {
+			m.log.Infof("💚 Health check [%s] recovered", c.name)
+		}
+	} else {
+		res.ConsecutiveFailures = prev.ConsecutiveFailures + 1
+		res.LastError = err.Error()
+		if res.ConsecutiveFailures >= m.threshold {
+			if prev.Status != StatusDown {
+				m.log.Warnf("💔 Health check [%s] down after %d failures: %v", c.name, res.ConsecutiveFailures, err)
+			}
+			res.Status = StatusDown
+		}
+	}
+	m.results[c.name] = res
+}
+
+// Report 마지막 점검 결과
+func (m *Monitor) Report() Report {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	report := Report{Status: StatusUp, Checks: make(map[string]CheckResult, len(m.results))}
+	for name, res := range m.results {
+		report.Checks[name] = res
+		if res.Critical && res.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+// Ready 모든 critical 점검이 up 인지
+func (m *Monitor) Ready() bool {
+	return m.Report().Status == StatusUp
+}
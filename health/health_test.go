@@ -0,0 +1,74 @@
+package health
+
+import (
+	"cache/config"
+	_interface "cache/interface"
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCheckGoesDownAfterThreshold(t *testing.T) {
+	m := NewMonitor(config.HealthConfig{FailureThreshold: 2})
+	var err error
+	m.Register("cache", true, func(context.Context) (map[string]interface{}, error) { return nil, err })
+	ctx := context.Background()
+
+	if m.Ready() {
+		t.Fatal("ready before the first check")
+	}
+	m.runAll(ctx)
+	if !m.Ready() {
+		t.Fatal("not ready after a passing check")
+	}
+
+	err = errors.New("connection refused")
+	m.runAll(ctx)
+	if !m.Ready() {
+		t.Fatal("one failure below the threshold should not flip readiness")
+	}
+	m.runAll(ctx)
+	res := m.Report().Checks["cache"]
+	if m.Ready() || res.Status != StatusDown || res.ConsecutiveFailures != 2 || res.LastError != "connection refused" {
+		t.Fatalf("after threshold: ready=%t result=%+v", m.Ready(), res)
+	}
+
+	err = nil
+	m.runAll(ctx)
+	if res := m.Report().Checks["cache"]; !m.Ready() || res.ConsecutiveFailures != 0 {
+		t.Fatalf("after recovery: ready=%t result=%+v", m.Ready(), res)
+	}
+}
+
+func TestNonCriticalCheckDoesNotAffectReadiness(t *testing.T) {
+	m := NewMonitor(config.HealthConfig{FailureThreshold: 1})
+	m.Register("cache", true, func(context.Context) (map[string]interface{}, error) { return nil, nil })
+	m.Register("broker", false, func(context.Context) (map[string]interface{}, error) { return nil, errors.New("down") })
+	m.runAll(context.Background())
+
+	report := m.Report()
+	if report.Status != StatusUp || report.Checks["broker"].Status != StatusDown {
+		t.Fatalf("report = %+v", report)
+	}
+}
+
+type groupBroker struct {
+	status _interface.ConsumerStatus
+}
+
+func (b groupBroker) Publish(context.Context, string, string) error              { return nil }
+func (b groupBroker) Subscribe(context.Context, _interface.MessageHandler) error { return nil }
+func (b groupBroker) Ping(context.Context) error                                 { return nil }
+func (b groupBroker) ConsumerStatus() _interface.ConsumerStatus                  { return b.status }
+func (b groupBroker) Close() error                                               { return nil }
+
+func TestGroupCheck(t *testing.T) {
+	ctx := context.Background()
+	details, err := GroupCheck(groupBroker{_interface.ConsumerStatus{GroupID: "g", MemberID: "m", Joined: true}})(ctx)
+	if err != nil || details["member_id"] != "m" {
+		t.Fatalf("joined: %v, %v", details, err)
+	}
+	if _, err := GroupCheck(groupBroker{_interface.ConsumerStatus{Error: "rebalance failed"}})(ctx); err == nil || err.Error() != "rebalance failed" {
+		t.Fatalf("not joined: err = %v", err)
+	}
+}
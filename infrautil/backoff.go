@@ -0,0 +1,68 @@
+package infrautil
+
+import (
+	"cache/config"
+	"context"
+	"math/rand"
+	"time"
+)
+
+// Backoff full jitter 지수 backoff
+type Backoff struct {
+	Base time.Duration
+	Max  time.Duration
+}
+
+func NewBackoff(cfg config.RetryConfig) Backoff {
+	b := Backoff{
+		Base: time.Duration(cfg.BackoffBaseMs) * time.Millisecond,
+		Max:  time.Duration(cfg.BackoffMaxMs) * time.Millisecond,
+	}
+	if b.Base <= 0 {
+		b.Base = 500 * time.Millisecond
+	}
+	if b.Max < b.Base {
+		b.Max = 30 * time.Second
+	}
+	return b
+}
+
+// Next attempt(1부터) 번째 재시도 전 대기 시간
+func (b Backoff) Next(attempt int) time.Duration {
+	d := b.Max
+	if attempt < 32 {
+		if exp := b.Base << (attempt - 1); exp > 0 && exp < b.Max {
+			d = exp
+		}
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// Sleep ctx 가 취소되면 false
+func (b Backoff) Sleep(ctx context.Context, attempt int) bool {
+	t := time.NewTimer(b.Next(attempt))
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+// Retry startup 연결 확인용. 최대 attempts 번 시도하고 마지막 에러 반환
+func Retry(ctx context.Context, b Backoff, attempts int, fn func(attempt int) error) error {
+	if attempts <= 0 {
+		attempts = 1
+	}
+	var err error
+	for i := 1; i <= attempts; i++ {
+		if err = fn(i); err == nil {
+			return nil
+		}
+		if i < attempts && !b.Sleep(ctx, i) {
+			return ctx.Err()
+		}
+	}
+	return err
+}
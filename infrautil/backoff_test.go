@@ -0,0 +1,60 @@
+package infrautil
+
+import (
+	"cache/config"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewBackoffDefaults(t *testing.T) {
+	b := NewBackoff(config.RetryConfig{})
+	if b.Base != 500*time.Millisecond || b.Max != 30*time.Second {
+		t.Fatalf("backoff = %+v", b)
+	}
+}
+
+func TestBackoffNextIsBounded(t *testing.T) {
+	b := Backoff{Base: 10 * time.Millisecond, Max: 100 * time.Millisecond}
+	for attempt := 1; attempt <= 64; attempt++ {
+		limit := b.Max
+		if attempt < 4 {
+			limit = b.Base << (attempt - 1)
+		}
+		for i := 0; i < 20; i++ {
+			if d := b.Next(attempt); d < 0 || d > limit {
+				t.Fatalf("Next(%d) = %s, want <= %s", attempt, d, limit)
+			}
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	b := Backoff{Base: time.Millisecond, Max: time.Millisecond}
+	calls := 0
+	err := Retry(context.Background(), b, 3, func(int) error {
+		calls++
+		if calls < 3 {
+			return errors.New("refused")
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Fatalf("Retry = %v after %d calls", err, calls)
+	}
+
+	calls = 0
+	boom := errors.New("refused")
+	if err := Retry(context.Background(), b, 2, func(int) error { calls++; return boom }); !errors.Is(err, boom) || calls != 2 {
+		t.Fatalf("Retry = %v after %d calls, want last error after 2", err, calls)
+	}
+}
+
+func TestSleepStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if (Backoff{Base: time.Hour, Max: time.Hour}).Sleep(ctx, 1) {
+		t.Fatal("Sleep returned true for a cancelled context")
+	}
+}
@@ -0,0 +1,141 @@
+package infrautil
+
+import (
+	"cache/config"
+	"cache/logger"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// CertReloader 서버 인증서와 client CA 를 주기적으로 확인해 파일이 바뀌면 다시 읽는다.
+// 새 파일을 읽지 못하면 기존 인증서를 계속 사용한다.
+type CertReloader struct {
+	cfg config.TLSConfig
+	log *zap.SugaredLogger
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	clientCA *x509.CertPool
+	modTimes map[string]time.Time
+}
+
+func NewCertReloader(cfg config.TLSConfig) (*CertReloader, error) {
+	c := &CertReloader{cfg: cfg, log: logger.Logger}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// TLSConfig 연결마다 최신 인증서와 client CA 를 사용하는 설정
+func (c *CertReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			c.mu.RLock()
+			defer c.mu.RUnlock()
+
+			conf := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*c.cert},
+			}
+			if c.clientCA != nil {
+				conf.ClientCAs = c.clientCA
+				conf.ClientAuth = tls.RequireAndVerifyClientCert
+			}
+			return conf, nil
+		},
+	}
+}
+
+// Watch ctx 가 끝날 때까지 reload_interval_seconds 마다 파일 변경 확인
+func (c *CertReloader) Watch(ctx context.Context) {
+	interval := time.Duration(c.cfg.ReloadIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !c.changed() {
+				continue
+			}
+			if err := c.load(); err != nil {
+				c.log.Errorf("❌ TLS certificate reload failed, keeping previous: %v", err)
+				continue
+			}
+			c.log.Infof("🔐 TLS certificates reloaded")
+		}
+	}
+}
+
+func (c *CertReloader) files() []string {
+	files := []string{c.cfg.CertFile, c.cfg.KeyFile}
+	if c.cfg.ClientCAFile != "" {
+		files = append(files, c.cfg.ClientCAFile)
+	}
+	return files
+}
+
+func (c *CertReloader) changed() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, f := range c.files() {
+		info, err := os.Stat(f)
+		if err != nil {
+			// 교체 중 잠시 없을 수 있으므로 다음 확인에서 다시 본다
+			continue
+		}
+		if !info.ModTime().Equal(c.modTimes[f]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *CertReloader) load() error {
+	modTimes := make(map[string]time.Time)
+	for _, f := range c.files() {
+		info, err := os.Stat(f)
+		if err != nil {
+			return fmt.Errorf("stat %s: %w", f, err)
+		}
+		modTimes[f] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.cfg.CertFile, c.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load key pair: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if c.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(c.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("read client ca: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in %s", c.cfg.ClientCAFile)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cert = &cert
+	c.clientCA = pool
+	c.modTimes = modTimes
+	return nil
+}
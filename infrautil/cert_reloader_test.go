@@ -0,0 +1,165 @@
+package infrautil
+
+import (
+	"cache/config"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+	kpem []byte
+}
+
+// newTestCert parent 가 nil 이면 self-signed CA
+func newTestCert(t *testing.T, name string, parent *testCert, usage x509.ExtKeyUsage) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	signer, signerKey := tmpl, key
+	if parent == nil {
+		tmpl.IsCA, tmpl.BasicConstraintsValid = true, true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	kder, _ := x509.MarshalECPrivateKey(key)
+	return &testCert{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		kpem: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: kder}),
+	}
+}
+
+func writeFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCertReloaderReloadsChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.TLSConfig{Enabled: true, CertFile: filepath.Join(dir, "tls.crt"), KeyFile: filepath.Join(dir, "tls.key")}
+	first := newTestCert(t, "first", nil, x509.ExtKeyUsageServerAuth)
+	writeFile(t, cfg.CertFile, first.pem)
+	writeFile(t, cfg.KeyFile, first.kpem)
+
+	c, err := NewCertReloader(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.changed() {
+		t.Fatal("changed right after load")
+	}
+
+	second := newTestCert(t, "second", nil, x509.ExtKeyUsageServerAuth)
+	writeFile(t, cfg.CertFile, second.pem)
+	writeFile(t, cfg.KeyFile, second.kpem)
+	future := time.Now().Add(time.Minute)
+	for _, f := range []string{cfg.CertFile, cfg.KeyFile} {
+		_ = os.Chtimes(f, future, future)
+	}
+	if !c.changed() {
+		t.Fatal("changed() = false after replacing the files")
+	}
+	if err := c.load(); err != nil {
+		t.Fatal(err)
+	}
+	if leaf, _ := x509.ParseCertificate(c.cert.Certificate[0]); leaf.Subject.CommonName != "second" {
+		t.Fatalf("serving %s, want second", leaf.Subject.CommonName)
+	}
+
+	// 잘못된 파일이면 기존 인증서를 유지한다
+	writeFile(t, cfg.KeyFile, []byte("broken"))
+	if err := c.load(); err == nil {
+		t.Fatal("expected error for a broken key")
+	}
+	if leaf, _ := x509.ParseCertificate(c.cert.Certificate[0]); leaf.Subject.CommonName != "second" {
+		t.Fatalf("serving %s after a failed reload, want second", leaf.Subject.CommonName)
+	}
+}
+
+func TestCertReloaderRequiresClientCert(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", nil, x509.ExtKeyUsageAny)
+	server := newTestCert(t, "server", ca, x509.ExtKeyUsageServerAuth)
+	client := newTestCert(t, "client", ca, x509.ExtKeyUsageClientAuth)
+	cfg := config.TLSConfig{
+		Enabled:      true,
+		CertFile:     filepath.Join(dir, "tls.crt"),
+		KeyFile:      filepath.Join(dir, "tls.key"),
+		ClientCAFile: filepath.Join(dir, "ca.crt"),
+	}
+	writeFile(t, cfg.CertFile, server.pem)
+	writeFile(t, cfg.KeyFile, server.kpem)
+	writeFile(t, cfg.ClientCAFile, ca.pem)
+
+	c, err := NewCertReloader(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {}))
+	srv.TLS = c.TLSConfig()
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	get := func(certs ...tls.Certificate) error {
+		hc := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: certs, ServerName: "localhost"}}}
+		resp, err := hc.Get(srv.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	if err := get(); err == nil {
+		t.Fatal("request without a client certificate succeeded")
+	}
+	pair, err := tls.X509KeyPair(client.pem, client.kpem)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := get(pair); err != nil {
+		t.Fatalf("request with a client certificate: %v", err)
+	}
+}
+
+func TestNewCertReloaderMissingFiles(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewCertReloader(config.TLSConfig{CertFile: filepath.Join(dir, "x.crt"), KeyFile: filepath.Join(dir, "x.key")}); err == nil {
+		t.Fatal("expected error for missing files")
+	}
+}
@@ -1,10 +1,10 @@
 package infrautil
 
 import (
+	"cache/logger"
+	"cache/metrics"
 	"context"
 	"go.uber.org/zap"
-	"os"
-	"time"
 )
 
 func LogConnectionResult(log *zap.SugaredLogger, name string, err error) {
@@ -15,12 +15,15 @@ func LogConnectionResult(log *zap.SugaredLogger, name string, err error) {
 	}
 }
 
-func RunMessageLoop(
+// RunMessageLoop ctx 가 끝날 때까지 메시지를 읽어 handler 로 전달한다.
+// 읽기 실패 시 종료하지 않고 backoff 후 재시도하며, 상태 변화는 onState 로 알린다.
+func RunMessageLoop[T any](
 	ctx context.Context,
 	log *zap.SugaredLogger,
-	maxFails int,
-	readFn func() (string, error),
-	handler func(string),
+	backoff Backoff,
+	readFn func() (T, error),
+	handler func(T),
+	onState func(failures int, err error),
 ) {
 	failCount := 0
 	ready := false
@@ -28,13 +31,18 @@ func RunMessageLoop(
 	for {
 		msg, err := readFn()
 		if err != nil {
+			if ctx.Err() != nil {
+				log.Infof("🛑 listener stopped")
+				return
+			}
+			metrics.BrokerConsumeErrors.Inc()
 			failCount++
-			log.Errorf("📉 message read failed (attempt %d/%d): %v", failCount, maxFails, err)
-			if failCount >= maxFails {
-				log.Error("❌ listener aborted after max retry limit")
-				os.Exit(1)
+			onState(failCount, err)
+			log.Errorf("📉 message read failed (attempt %d): %v", failCount, err)
+			if !backoff.Sleep(ctx, failCount) {
+				log.Infof("🛑 listener stopped")
+				return
 			}
-			time.Sleep(2 * time.Second)
 			continue
 		}
 
@@ -42,9 +50,13 @@ func RunMessageLoop(
 			log.Infof("✅ listener ready")
 			ready = true
 		}
+		if failCount > 0 {
+			log.Infof("💚 listener recovered after %d failed reads", failCount)
+			failCount = 0
+			onState(0, nil)
+		}
 
-		failCount = 0
-		log.Infof("📩 message received: %s", msg)
+		logger.With(ctx, log).Debugf("📩 message received: %v", msg)
 		handler(msg)
 	}
 }
@@ -0,0 +1,75 @@
+package infrautil
+
+import (
+	"cache/config"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRunMessageLoopLogsMessagesAtDebug(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	msgs := []string{"users:1", "users:2"}
+	var handled []string
+	read := func() (string, error) {
+		if len(msgs) == 0 {
+			cancel()
+			return "", errors.New("closed")
+		}
+		m := msgs[0]
+		msgs = msgs[1:]
+		return m, nil
+	}
+	RunMessageLoop(ctx, zap.New(core).Sugar(), NewBackoff(config.RetryConfig{}), read,
+		func(m string) { handled = append(handled, m) },
+		func(int, error) {})
+
+	if len(handled) != 2 {
+		t.Fatalf("handled = %v, want 2 messages", handled)
+	}
+	received := logs.FilterMessageSnippet("message received").All()
+	if len(received) != 2 {
+		t.Fatalf("message received logs = %d, want 2", len(received))
+	}
+	for _, e := range received {
+		if e.Level != zapcore.DebugLevel {
+			t.Fatalf("message received logged at %s, want debug", e.Level)
+		}
+	}
+}
+
+func TestRunMessageLoopRecoversFromReadErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results := []error{errors.New("broker down"), errors.New("broker down"), nil}
+	var states []int
+	handled := 0
+	read := func() (string, error) {
+		if len(results) == 0 {
+			cancel()
+			return "", ctx.Err()
+		}
+		err := results[0]
+		results = results[1:]
+		return "users:1", err
+	}
+	RunMessageLoop(ctx, zap.NewNop().Sugar(), Backoff{Base: time.Millisecond, Max: time.Millisecond}, read,
+		func(string) { handled++ },
+		func(failures int, _ error) { states = append(states, failures) })
+
+	if handled != 1 {
+		t.Fatalf("handled = %d, want 1", handled)
+	}
+	// 실패 횟수가 누적된 뒤 성공하면 0 으로 초기화된다
+	if len(states) != 3 || states[0] != 1 || states[1] != 2 || states[2] != 0 {
+		t.Fatalf("states = %v, want [1 2 0]", states)
+	}
+}
@@ -0,0 +1,98 @@
+package infrautil
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// limiterIdleTTL 이 시간 동안 사용되지 않은 bucket 은 제거
+const limiterIdleTTL = 10 * time.Minute
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RateLimiter key(클라이언트, topic 등) 별 token bucket.
+// rate 가 0 이하면 제한하지 않는다
+type RateLimiter struct {
+	rate  float64 // 초당 충전 token 수
+	burst float64
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if burst <= 0 {
+		burst = int(math.Max(1, math.Ceil(rate)))
+	}
+	return &RateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// AllowN key 의 bucket 에서 n 개 token 을 꺼낸다.
+// 부족하면 꺼내지 않고, 다시 시도할 수 있을 때까지의 대기 시간을 반환
+func (l *RateLimiter) AllowN(key string, n int) (bool, time.Duration) {
+	if l == nil || l.rate <= 0 {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	need := float64(n)
+	if need > l.burst {
+		// burst 보다 큰 요청은 영원히 통과할 수 없으므로 burst 만큼으로 취급
+		need = l.burst
+	}
+	if b.tokens >= need {
+		b.tokens -= need
+		return true, 0
+	}
+	wait := time.Duration((need - b.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+// sweep 오래 쓰이지 않은 bucket 정리. 호출자가 mu 를 잡고 있어야 한다
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < limiterIdleTTL {
+		return
+	}
+	l.lastSweep = now
+	for k, b := range l.buckets {
+		if now.Sub(b.last) > limiterIdleTTL {
+			delete(l.buckets, k)
+		}
+	}
+}
+
+// ReturnN AllowN 으로 꺼낸 n 개 token 을 되돌린다. 다음 단계에서 요청이 거절됐을 때 사용하며 burst 를 넘지 않는다
+func (l *RateLimiter) ReturnN(key string, n int) {
+	if l == nil || l.rate <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, ok := l.buckets[key]; ok {
+		b.tokens = math.Min(l.burst, b.tokens+float64(n))
+	}
+}
@@ -0,0 +1,104 @@
+package infrautil
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestLimiter(rate float64, burst int) (*RateLimiter, *time.Time) {
+	now := time.Unix(1_700_000_000, 0)
+	l := NewRateLimiter(rate, burst)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestRateLimiterBurstAndRefill(t *testing.T) {
+	l, now := newTestLimiter(2, 3)
+
+	for i := 0; i < 3; i++ {
+		if ok, _ := l.AllowN("svc", 1); !ok {
+			t.Fatalf("request %d rejected within burst", i)
+		}
+	}
+	ok, wait := l.AllowN("svc", 1)
+	if ok || wait != 500*time.Millisecond {
+		t.Fatalf("AllowN = %v, %s; want rejected with 500ms wait", ok, wait)
+	}
+
+	// 다른 key 의 bucket 은 따로 센다
+	if ok, _ := l.AllowN("other", 1); !ok {
+		t.Fatal("separate key shares a bucket")
+	}
+
+	*now = now.Add(500 * time.Millisecond)
+	if ok, _ := l.AllowN("svc", 1); !ok {
+		t.Fatal("token was not refilled")
+	}
+}
+
+func TestRateLimiterAllowN(t *testing.T) {
+	l, now := newTestLimiter(10, 5)
+
+	if ok, _ := l.AllowN("svc", 4); !ok {
+		t.Fatal("batch within burst rejected")
+	}
+	if ok, _ := l.AllowN("svc", 2); ok {
+		t.Fatal("batch beyond remaining tokens allowed")
+	}
+	// burst 보다 큰 배치도 bucket 이 가득 차면 통과한다
+	*now = now.Add(time.Second)
+	if ok, _ := l.AllowN("svc", 50); !ok {
+		t.Fatal("oversized batch rejected with a full bucket")
+	}
+}
+
+func TestRateLimiterReturnN(t *testing.T) {
+	l, _ := newTestLimiter(1, 2)
+
+	l.AllowN("svc", 2)
+	l.ReturnN("svc", 1)
+	if ok, _ := l.AllowN("svc", 1); !ok {
+		t.Fatal("returned token was not usable")
+	}
+	// burst 를 넘겨 되돌리지 않는다
+	l.ReturnN("svc", 5)
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.AllowN("svc", 1); !ok {
+			t.Fatalf("request %d rejected after ReturnN", i)
+		}
+	}
+	if ok, _ := l.AllowN("svc", 1); ok {
+		t.Fatal("ReturnN exceeded burst")
+	}
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	var nilLimiter *RateLimiter
+	for _, l := range []*RateLimiter{nilLimiter, NewRateLimiter(0, 0)} {
+		for i := 0; i < 100; i++ {
+			if ok, _ := l.AllowN("svc", 1); !ok {
+				t.Fatal("disabled limiter rejected a request")
+			}
+		}
+	}
+}
+
+func TestRateLimiterDefaultBurst(t *testing.T) {
+	if l := NewRateLimiter(2.5, 0); l.burst != 3 {
+		t.Fatalf("burst = %v, want 3", l.burst)
+	}
+	if l := NewRateLimiter(0.1, 0); l.burst != 1 {
+		t.Fatalf("burst = %v, want 1", l.burst)
+	}
+}
+
+func TestRateLimiterSweepsIdleBuckets(t *testing.T) {
+	l, now := newTestLimiter(1, 1)
+	l.AllowN("old", 1)
+
+	*now = now.Add(limiterIdleTTL + time.Second)
+	l.AllowN("new", 1)
+	if _, ok := l.buckets["old"]; ok {
+		t.Fatal("idle bucket was not removed")
+	}
+}
@@ -0,0 +1,53 @@
+package infrautil
+
+import (
+	"cache/metrics"
+	"hash/fnv"
+	"sync"
+)
+
+// KeyedPool 같은 key 는 항상 같은 worker 가 처리해 순서를 보장하면서 서로 다른 key 는 병렬 처리한다
+type KeyedPool[T any] struct {
+	queues []chan T
+	wg     sync.WaitGroup
+}
+
+// NewKeyedPool worker 별 queue 는 queueSize 로 제한되며 가득 차면 Submit 이 대기한다
+func NewKeyedPool[T any](workers int, queueSize int, fn func(T)) *KeyedPool[T] {
+	if workers <= 0 {
+		workers = 1
+	}
+	if queueSize <= 0 {
+		queueSize = 1
+	}
+
+	p := &KeyedPool[T]{queues: make([]chan T, workers)}
+	for i := range p.queues {
+		q := make(chan T, queueSize)
+		p.queues[i] = q
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			for item := range q {
+				metrics.WorkerQueueDepth.Dec()
+				fn(item)
+			}
+		}()
+	}
+	return p
+}
+
+func (p *KeyedPool[T]) Submit(key string, item T) {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	metrics.WorkerQueueDepth.Inc()
+	p.queues[h.Sum32()%uint32(len(p.queues))] <- item
+}
+
+// Close 더 이상 Submit 하지 않으며 남은 작업이 끝날 때까지 기다린다
+func (p *KeyedPool[T]) Close() {
+	for _, q := range p.queues {
+		close(q)
+	}
+	p.wg.Wait()
+}
@@ -0,0 +1,71 @@
+package infrautil
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type job struct {
+	key string
+	seq int
+}
+
+func TestKeyedPoolKeepsOrderPerKey(t *testing.T) {
+	var mu sync.Mutex
+	seen := make(map[string][]int)
+	p := NewKeyedPool(4, 8, func(j job) {
+		mu.Lock()
+		defer mu.Unlock()
+		seen[j.key] = append(seen[j.key], j.seq)
+	})
+
+	for seq := 0; seq < 100; seq++ {
+		for k := 0; k < 5; k++ {
+			key := fmt.Sprintf("partition-%d", k)
+			p.Submit(key, job{key: key, seq: seq})
+		}
+	}
+	p.Close()
+
+	for key, seqs := range seen {
+		if len(seqs) != 100 {
+			t.Fatalf("%s processed %d jobs, want 100", key, len(seqs))
+		}
+		for i, seq := range seqs {
+			if seq != i {
+				t.Fatalf("%s out of order at %d: %v", key, i, seqs[:i+1])
+			}
+		}
+	}
+}
+
+func TestKeyedPoolRunsKeysInParallel(t *testing.T) {
+	var running, peak atomic.Int32
+	release := make(chan struct{})
+	p := NewKeyedPool(8, 1, func(int) {
+		n := running.Add(1)
+		for {
+			old := peak.Load()
+			if n <= old || peak.CompareAndSwap(old, n) {
+				break
+			}
+		}
+		<-release
+		running.Add(-1)
+	})
+
+	// 서로 다른 worker 로 가는 key 를 찾을 때까지 제출한다
+	for i := 0; i < 64 && peak.Load() < 2; i++ {
+		p.Submit(fmt.Sprintf("key-%d", i), i)
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	p.Close()
+
+	if peak.Load() < 2 {
+		t.Fatal("different keys were never processed concurrently")
+	}
+}
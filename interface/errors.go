@@ -0,0 +1,10 @@
+package _interface
+
+import "errors"
+
+var (
+	// ErrNotFound 캐시 미스. ICacheAdapter.Get 은 값이 없으면 빈 문자열과 함께 이 에러를 반환한다
+	ErrNotFound = errors.New("cache miss")
+	// ErrUnavailable cache backend 에 연결할 수 없거나 시간 안에 응답하지 않음. 구체적인 원인은 감싸서 전달
+	ErrUnavailable = errors.New("cache backend unavailable")
+)
@@ -1,18 +1,95 @@
 package _interface
 
+import (
+	"context"
+	"time"
+)
+
 type ICacheAdapter interface {
-	Get(key string) (string, error)
-	Set(key string, value string, ttlSeconds int) error
-	Invalidate(key string) error
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value string, ttlSeconds int) error
+	Invalidate(ctx context.Context, key string) error
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// Message 브로커로부터 수신한 invalidation 메시지
+type Message struct {
+	Topic       string
+	Key         string
+	Tenant      string    // 발행한 요청의 tenant, tenancy 를 쓰지 않으면 빈 문자열
+	Timestamp   time.Time // 발행 시각
+	Destination string    // 메시지가 실려 온 broker 의 topic (예: Kafka topic). 모르면 빈 문자열
+}
+
+func (m Message) String() string {
+	if m.Tenant != "" {
+		return m.Tenant + "/" + m.Topic + ":" + m.Key
+	}
+	return m.Topic + ":" + m.Key
 }
 
+// MessageHandler 수신한 메시지 처리. 에러를 반환하면 broker 가 재시도하며 처리 완료 전에는 commit 하지 않는다
+type MessageHandler func(ctx context.Context, msg Message) error
+
 type IEventBroker interface {
-	Publish(topic string, key string) error
-	PublishTo(topic string, key string) error
-	Subscribe(handler func(topic string, key string)) error
+	// Publish 캐시 topic 의 key 무효화를 다른 노드에 전파. 어느 broker topic 으로 보낼지는 broker 가 정한다
+	Publish(ctx context.Context, topic string, key string) error
+	// Subscribe ctx 가 끝날 때까지 메시지를 수신해 handler 를 호출한다 (비동기)
+	Subscribe(ctx context.Context, handler MessageHandler) error
+	Ping(ctx context.Context) error
+	ConsumerStatus() ConsumerStatus
+	// Close 수신을 멈추고 처리 중인 메시지를 기다린 뒤 대기 중인 발행을 flush 한다
+	Close() error
+}
+
+// DeadLetter 처리에 실패해 DLQ 에 보관된 메시지
+type DeadLetter struct {
+	Partition  int       `json:"partition"`
+	Offset     int64     `json:"offset"`
+	Topic      string    `json:"topic"`       // 캐시 topic
+	KafkaTopic string    `json:"kafka_topic"` // 처음 발행된 Kafka topic
+	Key        string    `json:"key"`
+	Tenant     string    `json:"tenant,omitempty"`
+	Attempts   int       `json:"attempts"`
+	Error      string    `json:"error"`
+	FailedAt   time.Time `json:"failed_at"`
+}
+
+// IDeadLetterQueue DLQ 조회 및 재발행
+type IDeadLetterQueue interface {
+	DeadLetters(ctx context.Context, limit int) ([]DeadLetter, error)
+	// ReplayDeadLetter DLQ 메시지를 원래 Kafka topic 으로 다시 발행
+	ReplayDeadLetter(ctx context.Context, partition int, offset int64) error
+}
+
+// IEventFeed 다른 노드가 처리한 것까지 모든 invalidation 을 이 노드에서 관찰.
+// Subscribe 와 달리 consumer group 으로 나눠 받지 않고, handler 실패도 재시도하지 않는다
+type IEventFeed interface {
+	// Feed 지금부터 발행되는 메시지를 ctx 가 끝날 때까지 handler 로 전달한다 (비동기)
+	Feed(ctx context.Context, handler MessageHandler) error
+}
+
+// ITopicManager 실행 중 구독 topic 추가
+type ITopicManager interface {
+	AddTopics(topics []string) error
+}
+
+// ConsumerStatus consumer group 참여 및 수신 상태. group 개념이 없는 broker 는 Joined=true
+type ConsumerStatus struct {
+	GroupID  string
+	MemberID string
+	Joined   bool
+	Error    string // group 참여 실패 사유
+
+	ReadFailures int    // 연속 수신 실패 횟수
+	ReadError    string // 마지막 수신 실패 사유
+
+	Replaying bool // 시작 시 놓친 이벤트를 재적용하는 중
 }
 
 type IInvalidationStrategy interface {
 	GenerateKey(topic string, key string) string
-	ComputeTTL(baseTTL int) int
+	// ComputeTTL 요청 TTL 이 0 이면 topic 별 기본 TTL 을 적용
+	ComputeTTL(topic string, baseTTL int) int
 }
@@ -0,0 +1,76 @@
+package lifecycle
+
+import (
+	"cache/logger"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type hook struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// Manager 종료 시 등록 순서대로 hook 을 실행한다. 전체 hook 은 하나의 deadline 을 공유
+type Manager struct {
+	timeout time.Duration
+	hooks   []hook
+	log     *zap.SugaredLogger
+}
+
+func NewManager(timeout time.Duration) *Manager {
+	if timeout <= 0 {
+		timeout = 15 * time.Second
+	}
+	return &Manager{timeout: timeout, log: logger.Logger}
+}
+
+// OnShutdown 종료 hook 등록
+func (m *Manager) OnShutdown(name string, fn func(ctx context.Context) error) {
+	m.hooks = append(m.hooks, hook{name: name, fn: fn})
+}
+
+// Wait SIGINT/SIGTERM 을 받을 때까지 대기
+func (m *Manager) Wait() os.Signal {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sig)
+	return <-sig
+}
+
+// Shutdown 모든 hook 실행. deadline 을 넘겨도 남은 hook 은 실행해 자원을 최대한 정리한다
+func (m *Manager) Shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	var errs []error
+	for _, h := range m.hooks {
+		start := time.Now()
+		if err := m.run(ctx, h); err != nil {
+			m.log.Errorf("❌ shutdown [%s] failed: %v", h.name, err)
+			errs = append(errs, fmt.Errorf("%s: %w", h.name, err))
+			continue
+		}
+		m.log.Infof("✅ shutdown [%s] done in %s", h.name, time.Since(start).Round(time.Millisecond))
+	}
+	return errors.Join(errs...)
+}
+
+// run hook 이 deadline 안에 끝나지 않으면 기다리지 않고 다음 hook 으로 넘어간다
+func (m *Manager) run(ctx context.Context, h hook) error {
+	done := make(chan error, 1)
+	go func() { done <- h.fn(ctx) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,88 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestShutdownRunsHooksInOrder(t *testing.T) {
+	m := NewManager(time.Second)
+	var order []string
+	for _, name := range []string{"http", "listener", "broker", "cache"} {
+		name := name
+		m.OnShutdown(name, func(context.Context) error {
+			order = append(order, name)
+			return nil
+		})
+	}
+
+	if err := m.Shutdown(); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(order, ","); got != "http,listener,broker,cache" {
+		t.Fatalf("order = %s", got)
+	}
+}
+
+func TestShutdownContinuesAfterFailure(t *testing.T) {
+	m := NewManager(time.Second)
+	ran := false
+	m.OnShutdown("broker", func(context.Context) error { return errors.New("flush failed") })
+	m.OnShutdown("cache", func(context.Context) error {
+		ran = true
+		return nil
+	})
+
+	err := m.Shutdown()
+	if err == nil || !strings.Contains(err.Error(), "broker: flush failed") {
+		t.Fatalf("err = %v", err)
+	}
+	if !ran {
+		t.Fatal("hook after a failed hook did not run")
+	}
+}
+
+func TestShutdownDoesNotWaitPastDeadline(t *testing.T) {
+	m := NewManager(50 * time.Millisecond)
+	block := make(chan struct{})
+	defer close(block)
+	started := make(chan struct{})
+	m.OnShutdown("stuck", func(context.Context) error {
+		<-block
+		return nil
+	})
+	m.OnShutdown("cache", func(context.Context) error {
+		close(started)
+		return nil
+	})
+
+	start := time.Now()
+	err := m.Shutdown()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Shutdown took %s", elapsed)
+	}
+	// deadline 이후의 hook 도 시작은 된다
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("hooks after the deadline should still run")
+	}
+}
+
+func TestWaitReturnsSignal(t *testing.T) {
+	m := NewManager(time.Second)
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		_ = syscall.Kill(syscall.Getpid(), syscall.SIGTERM)
+	}()
+	if sig := m.Wait(); sig != syscall.SIGTERM {
+		t.Fatalf("signal = %v", sig)
+	}
+}
@@ -1,14 +1,25 @@
 package logger
 
 import (
+	"cache/config"
+	"cache/requestid"
+	"context"
+	"fmt"
+
 	"go.uber.org/zap"
 	zapCore "go.uber.org/zap/zapcore"
 )
 
-var Logger *zap.SugaredLogger
+// Logger Init/Configure 전에는 아무것도 출력하지 않는다
+var Logger = zap.NewNop().Sugar()
+
+// level 실행 중 변경 가능한 로그 레벨
+var level = zap.NewAtomicLevelAt(zapCore.DebugLevel)
 
+// Init 설정을 읽기 전에 쓰는 개발용 console logger. 설정을 읽은 뒤 Configure 로 교체한다
 func Init() {
 	cfg := zap.NewDevelopmentConfig()
+	cfg.Level = level
 	cfg.Encoding = "console"
 	cfg.EncoderConfig.TimeKey = "ts"
 	cfg.EncoderConfig.LevelKey = "level"
@@ -20,3 +31,53 @@ func Init() {
 	base, _ := cfg.Build()
 	Logger = base.Sugar()
 }
+
+// Configure logging 설정으로 Logger 를 다시 만든다. 컴포넌트가 Logger 를 잡아두기 전에 호출해야 한다
+func Configure(cfg config.LoggingConfig) error {
+	if err := SetLevel(cfg.Level); err != nil {
+		return fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+	}
+
+	zc := zap.NewProductionConfig()
+	zc.Level = level
+	zc.Encoding = cfg.Format
+	zc.EncoderConfig.TimeKey = "ts"
+	zc.EncoderConfig.EncodeTime = zapCore.ISO8601TimeEncoder
+	if cfg.Format == "console" {
+		zc.Development = true
+		zc.EncoderConfig.EncodeLevel = zapCore.CapitalLevelEncoder
+		if cfg.File == "" {
+			// 터미널로 나갈 때만 색상 사용
+			zc.EncoderConfig.EncodeLevel = zapCore.CapitalColorLevelEncoder
+		}
+	}
+	zc.OutputPaths = []string{"stderr"}
+	if cfg.File != "" {
+		zc.OutputPaths = []string{cfg.File}
+	}
+	zc.Sampling = nil
+	if cfg.Sampling.Enabled {
+		zc.Sampling = &zap.SamplingConfig{Initial: cfg.Sampling.Initial, Thereafter: cfg.Sampling.Thereafter}
+	}
+
+	base, err := zc.Build()
+	if err != nil {
+		return fmt.Errorf("build logger: %w", err)
+	}
+	_ = Logger.Sync()
+	Logger = base.Sugar()
+	return nil
+}
+
+// SetLevel debug, info, warn, error 중 하나로 로그 레벨 변경
+func SetLevel(name string) error {
+	return level.UnmarshalText([]byte(name))
+}
+
+// With ctx 에 request ID 가 있으면 필드로 붙인 logger. 없으면 log 그대로
+func With(ctx context.Context, log *zap.SugaredLogger) *zap.SugaredLogger {
+	if id := requestid.From(ctx); id != "" {
+		return log.With("request_id", id)
+	}
+	return log
+}
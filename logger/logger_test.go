@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"cache/config"
+	"cache/requestid"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// configureFile cfg 로 Logger 를 파일에 쓰도록 바꾸고, 테스트가 끝나면 원래 Logger 와 레벨로 되돌린다
+func configureFile(t *testing.T, cfg config.LoggingConfig) string {
+	t.Helper()
+	prev, prevLevel := Logger, level.Level()
+	t.Cleanup(func() {
+		Logger = prev
+		level.SetLevel(prevLevel)
+	})
+
+	cfg.File = filepath.Join(t.TempDir(), "cache.log")
+	if err := Configure(cfg); err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+	return cfg.File
+}
+
+func readLines(t *testing.T, file string) []string {
+	t.Helper()
+	_ = Logger.Sync()
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestConfigureJSON(t *testing.T) {
+	file := configureFile(t, config.LoggingConfig{Level: "info", Format: "json"})
+
+	Logger.Debugw("hidden")
+	Logger.Infow("cache hit", "topic", "users")
+
+	lines := readLines(t, file)
+	if len(lines) != 1 {
+		t.Fatalf("lines = %q, want only the info line", lines)
+	}
+	var rec map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &rec); err != nil {
+		t.Fatalf("line %q is not JSON: %v", lines[0], err)
+	}
+	if rec["msg"] != "cache hit" || rec["topic"] != "users" || rec["level"] != "info" || rec["ts"] == nil {
+		t.Fatalf("record = %v", rec)
+	}
+}
+
+func TestConfigureConsoleFile(t *testing.T) {
+	file := configureFile(t, config.LoggingConfig{Level: "debug", Format: "console"})
+
+	Logger.Infow("slow redis", "ms", 120)
+	lines := readLines(t, file)
+	// 파일로 나갈 때는 색상 escape 코드를 쓰지 않는다
+	if len(lines) != 1 || !strings.Contains(lines[0], "INFO") || strings.Contains(lines[0], "\x1b[") {
+		t.Fatalf("lines = %q", lines)
+	}
+}
+
+func TestConfigureSampling(t *testing.T) {
+	file := configureFile(t, config.LoggingConfig{
+		Level:    "info",
+		Format:   "json",
+		Sampling: config.LogSamplingConfig{Enabled: true, Initial: 2, Thereafter: 1000},
+	})
+
+	for i := 0; i < 10; i++ {
+		Logger.Info("same message")
+	}
+	if lines := readLines(t, file); len(lines) != 2 {
+		t.Fatalf("logged %d lines, want 2", len(lines))
+	}
+}
+
+func TestConfigureRejectsBadLevel(t *testing.T) {
+	prev := Logger
+	defer func() { Logger = prev }()
+
+	if err := Configure(config.LoggingConfig{Level: "verbose", Format: "json"}); err == nil {
+		t.Fatal("expected error for unknown level")
+	}
+	if Logger != prev {
+		t.Fatal("Logger replaced despite invalid config")
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	prev := level.Level()
+	defer level.SetLevel(prev)
+
+	if err := SetLevel("warn"); err != nil {
+		t.Fatal(err)
+	}
+	if level.Enabled(zap.InfoLevel) || !level.Enabled(zap.WarnLevel) {
+		t.Fatalf("level = %s", level.Level())
+	}
+	if err := SetLevel("loud"); err == nil {
+		t.Fatal("expected error for unknown level")
+	}
+}
+
+func TestWithRequestID(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	log := zap.New(core).Sugar()
+
+	With(context.Background(), log).Info("no id")
+	With(requestid.WithContext(context.Background(), "req-1"), log).Info("with id")
+
+	entries := logs.All()
+	if len(entries) != 2 {
+		t.Fatalf("entries = %d", len(entries))
+	}
+	if _, ok := entries[0].ContextMap()["request_id"]; ok {
+		t.Fatal("request_id attached without one in ctx")
+	}
+	if got := entries[1].ContextMap()["request_id"]; got != "req-1" {
+		t.Fatalf("request_id = %v", got)
+	}
+}
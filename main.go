@@ -1,20 +1,38 @@
 package main
 
 import (
+	"cache/auth"
 	"cache/config"
 	"cache/core"
+	"cache/grpc_server"
 	"cache/handler"
+	"cache/health"
+	"cache/infrautil"
+	"cache/lifecycle"
 	"cache/logger"
+	"cache/metrics"
+	"cache/reload"
+	"cache/tenant"
+	"cache/tracing"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"go.uber.org/zap"
 	"log"
+	"net"
 	"net/http"
 	"os"
-	"os/signal"
-	"syscall"
+	"time"
 )
 
 func main() {
+	configPath := flag.String("config", "config.yaml", "config file path")
+	listenAddr := flag.String("listen", "", "HTTP listen address (overrides http.address)")
+	logLevel := flag.String("log-level", "", "log level: debug, info, warn, error (overrides logging.level)")
+	checkConfig := flag.Bool("check-config", false, "validate the config and exit (non-zero on error)")
+	flag.Parse()
+
 	// 1. Init logger
 	logger.Init()
 	defer func(Log *zap.SugaredLogger) {
@@ -25,10 +43,40 @@ func main() {
 	}(logger.Logger)
 
 	// 2. Load config
-	conf, err := config.Load("config.yaml")
+	// 우선순위: flag > 환경 변수 > 설정 파일 > 기본값
+	overrides := map[string]interface{}{}
+	if *listenAddr != "" {
+		overrides["http.address"] = *listenAddr
+	}
+	if *logLevel != "" {
+		overrides["logging.level"] = *logLevel
+	}
+	conf, err := config.Load(*configPath, overrides)
 	if err != nil {
 		log.Fatalf("❌ config load failed: %v", err)
 	}
+	if err := conf.Validate(); err != nil {
+		if *checkConfig {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		log.Fatalf("❌ %v", err)
+	}
+	if *checkConfig {
+		fmt.Println("✅ config OK")
+		return
+	}
+	if err := logger.Configure(conf.Logging); err != nil {
+		log.Fatalf("❌ logger init failed: %v", err)
+	}
+	metrics.SetTopics(conf.MetricTopics())
+
+	lc := lifecycle.NewManager(time.Duration(conf.Shutdown.TimeoutSeconds) * time.Second)
+
+	shutdownTracing, err := tracing.Init(conf.Tracing)
+	if err != nil {
+		log.Fatalf("❌ tracing init failed: %v", err)
+	}
 
 	// 3. Initialize components
 	cacheAdapter, err := core.NewCacheAdapter(conf.Cache)
@@ -48,30 +96,141 @@ func main() {
 
 	// 4. Setup services
 	cacheService := core.NewCacheService(cacheAdapter, strategy)
+	cacheService.SetQuota(conf.Cache.Quota)
 	eventListener := core.NewEventListener(eventBroker, cacheService)
 
+	monitor := health.NewMonitor(conf.Health)
+	// breaker 가 켜져 있으면 cache 장애 중에도 degraded mode 로 서비스하므로 readiness 에서 제외
+	monitor.Register("cache", !conf.Cache.Breaker.Enabled, health.CacheCheck(cacheAdapter))
+	monitor.Register("broker", true, health.BrokerCheck(eventBroker))
+	monitor.Register("consumer_group", false, health.GroupCheck(eventBroker))
+	monitor.Register("listener", true, health.ListenerCheck(eventListener))
+
+	monitorCtx, stopMonitor := context.WithCancel(context.Background())
+
 	// 5. Setup router
-	mux := handler.NewRouter(cacheService, eventBroker)
+	var guard *auth.Guard
+	if conf.Auth.Enabled {
+		guard, err = auth.NewGuard(monitorCtx, conf.Auth)
+		if err != nil {
+			log.Fatalf("❌ auth init failed: %v", err)
+		}
+	}
+	tenants := tenant.NewResolver(conf.Tenancy)
+	eventListener.SetTenants(tenants)
+	limits := handler.NewRateLimiter(conf.RateLimit)
+	mux := handler.NewRouter(cacheService, eventBroker, eventListener, conf.Stream, monitor, guard, limits, tenants, handler.NewAccessLog(conf.Logging))
 
 	// 6. Start listener async
-	go eventListener.Start()
+	listenerCtx, stopListener := context.WithCancel(context.Background())
+	eventListener.Start(listenerCtx)
 	fmt.Println("✅ Event listener started.")
 
+	go monitor.Start(monitorCtx)
+
+	// SIGHUP 또는 설정 파일 변경 시 안전한 설정만 다시 적용
+	reloader := reload.NewReloader(*configPath, overrides, conf, cacheService, eventBroker)
+	go reloader.Watch(monitorCtx)
+
 	// 7. Start HTTP server
+	// 종료 시 base context 를 취소해 SSE/WebSocket 같은 장기 연결도 끝나도록 한다
+	baseCtx, cancelBase := context.WithCancel(context.Background())
+	httpServer := newHTTPServer(conf.HTTP, handler.MaxBodyMiddleware(conf.HTTP.MaxBodyBytes)(mux))
+	httpServer.BaseContext = func(net.Listener) context.Context { return baseCtx }
+	httpServer.RegisterOnShutdown(cancelBase)
+	if conf.HTTP.TLS.Enabled {
+		certs, err := infrautil.NewCertReloader(conf.HTTP.TLS)
+		if err != nil {
+			log.Fatalf("❌ TLS init failed: %v", err)
+		}
+		httpServer.TLSConfig = certs.TLSConfig()
+		go certs.Watch(monitorCtx)
+	}
 	go func() {
-		fmt.Println("🚀 HTTP server running on :8000")
-		if err := http.ListenAndServe(":8000", mux); err != nil {
+		fmt.Printf("🚀 HTTP server running on %s (tls=%t)\n", conf.HTTP.Address, conf.HTTP.TLS.Enabled)
+		var err error
+		if conf.HTTP.TLS.Enabled {
+			// 인증서는 TLSConfig 에서 제공
+			err = httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = httpServer.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			log.Fatalf("❌ HTTP server error: %v", err)
 		}
 	}()
+	lc.OnShutdown("http", httpServer.Shutdown)
 
-	// 8. Wait for termination
-	waitForExit()
+	// 8. Start gRPC server
+	if conf.GRPC.Enabled {
+		grpcServer := grpc_server.NewServer(cacheService, eventBroker, eventListener, tenants, guard, limits)
+		go func() {
+			lis, err := net.Listen("tcp", conf.GRPC.Address)
+			if err != nil {
+				log.Fatalf("❌ gRPC listen error: %v", err)
+			}
+			fmt.Printf("🚀 gRPC server running on %s\n", conf.GRPC.Address)
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Fatalf("❌ gRPC server error: %v", err)
+			}
+		}()
+		lc.OnShutdown("grpc", func(ctx context.Context) error {
+			done := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-ctx.Done():
+				// watch stream 등이 남아 있으면 강제 종료
+				grpcServer.Stop()
+			}
+			return nil
+		})
+	}
+
+	lc.OnShutdown("listener", func(ctx context.Context) error {
+		stopListener()
+		stopMonitor()
+		return nil
+	})
+	lc.OnShutdown("event broker", func(ctx context.Context) error {
+		return eventBroker.Close()
+	})
+	lc.OnShutdown("cache adapter", func(ctx context.Context) error {
+		return cacheAdapter.Close()
+	})
+	if guard != nil {
+		lc.OnShutdown("auth audit log", func(ctx context.Context) error {
+			return guard.Close()
+		})
+	}
+	lc.OnShutdown("tracing", shutdownTracing)
+
+	// 9. Wait for termination
+	waitForExit(lc)
 }
 
-func waitForExit() {
-	sig := make(chan os.Signal, 1)
-	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
-	<-sig
+func waitForExit(lc *lifecycle.Manager) {
+	lc.Wait()
 	fmt.Println("\n🛑 Goro shutting down.")
+	if err := lc.Shutdown(); err != nil {
+		logger.Logger.Errorf("❌ shutdown finished with errors: %v", err)
+		return
+	}
+	fmt.Println("👋 Goro stopped.")
+}
+
+// newHTTPServer slowloris 등으로 연결이 묶이지 않도록 timeout 과 헤더 크기를 제한한 서버
+func newHTTPServer(cfg config.HTTPConfig, h http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              cfg.Address,
+		Handler:           h,
+		ReadTimeout:       time.Duration(cfg.ReadTimeoutSeconds) * time.Second,
+		ReadHeaderTimeout: time.Duration(cfg.ReadHeaderTimeoutSeconds) * time.Second,
+		WriteTimeout:      time.Duration(cfg.WriteTimeoutSeconds) * time.Second,
+		IdleTimeout:       time.Duration(cfg.IdleTimeoutSeconds) * time.Second,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
 }
@@ -0,0 +1,211 @@
+package metrics
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "cache"
+
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+// Cache adapter
+var (
+	CacheRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "requests_total",
+		Help:      "Cache adapter operations by configured topic (or other) and result (hit, miss, ok, error).",
+	}, []string{"op", "topic", "result"})
+
+	CacheLatency = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "adapter_duration_seconds",
+		Help:      "Cache adapter operation latency.",
+		Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 14),
+	}, []string{"op"})
+)
+
+// Circuit breaker
+var (
+	BreakerState = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "breaker_state",
+		Help:      "Cache backend circuit breaker state (0=closed, 1=half-open, 2=open).",
+	})
+
+	BreakerTransitions = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "breaker_transitions_total",
+		Help:      "Circuit breaker state transitions by target state.",
+	}, []string{"state"})
+
+	DegradedOps = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "degraded_operations_total",
+		Help:      "Cache operations served in degraded mode by fallback action.",
+	}, []string{"op", "action"})
+
+	DegradedQueueDepth = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "degraded_queue_depth",
+		Help:      "Writes queued while the cache backend is unavailable.",
+	})
+)
+
+// Event broker
+var (
+	BrokerPublished = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "broker_published_total",
+		Help:      "Invalidation messages published to the event broker.",
+	}, []string{"topic"})
+
+	BrokerPublishErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "broker_publish_errors_total",
+		Help:      "Failed invalidation publishes.",
+	}, []string{"topic"})
+
+	BrokerConsumed = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "broker_consumed_total",
+		Help:      "Invalidation messages consumed from the event broker.",
+	}, []string{"topic"})
+
+	BrokerConsumeErrors = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "broker_consume_errors_total",
+		Help:      "Failed reads from the event broker.",
+	})
+
+	BrokerHandlerErrors = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "broker_handler_errors_total",
+		Help:      "Failed attempts to process a consumed invalidation message.",
+	}, []string{"topic"})
+
+	BrokerRetried = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "broker_retried_total",
+		Help:      "Messages sent to the retry topic after exhausting handler retries.",
+	}, []string{"topic"})
+
+	BrokerDeadLettered = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "broker_dead_lettered_total",
+		Help:      "Messages sent to the dead letter topic.",
+	}, []string{"topic"})
+
+	BrokerCommits = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "broker_commits_total",
+		Help:      "Offset commit batches sent to the event broker.",
+	})
+
+	BrokerCommitErrors = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "broker_commit_errors_total",
+		Help:      "Failed offset commits.",
+	})
+
+	WorkerQueueDepth = factory.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "worker_queue_depth",
+		Help:      "Consumed messages waiting for an invalidation worker.",
+	})
+
+	InvalidationDelay = factory.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "invalidation_delay_seconds",
+		Help:      "Delay between an invalidation being published and the local delete completing.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 16),
+	})
+
+	lagSource atomic.Value // func() int64
+
+	_ = factory.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "broker_consumer_lag",
+		Help:      "Messages between the consumer offset and the partition high watermark.",
+	}, func() float64 {
+		if fn, ok := lagSource.Load().(func() int64); ok {
+			return float64(fn())
+		}
+		return 0
+	})
+)
+
+// Rate limit / quota
+var (
+	Rejections = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rejections_total",
+		Help:      "Requests rejected by rate limits or topic quotas by reason (client_rate, topic_rate, quota_max_keys, quota_max_bytes).",
+	}, []string{"reason", "topic"})
+)
+
+// HTTP
+var (
+	HTTPRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by route, method and status code.",
+	}, []string{"route", "method", "status"})
+
+	HTTPLatency = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by route.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// OtherTopic 설정에 없는 topic 의 label. 클라이언트가 보낸 topic 마다 시계열이 생기지 않게 한다
+const OtherTopic = "other"
+
+var knownTopics atomic.Value // map[string]string, 소문자 topic -> label
+
+// SetTopics label 로 구분할 topic 목록 교체. 대소문자는 구분하지 않는다
+func SetTopics(topics []string) {
+	known := make(map[string]string, len(topics))
+	for _, t := range topics {
+		if _, ok := known[strings.ToLower(t)]; !ok && t != "" {
+			known[strings.ToLower(t)] = t
+		}
+	}
+	knownTopics.Store(known)
+}
+
+// Topic metric 의 topic label. SetTopics 에 없는 topic 은 OtherTopic
+func Topic(topic string) string {
+	known, _ := knownTopics.Load().(map[string]string)
+	if label, ok := known[strings.ToLower(topic)]; ok {
+		return label
+	}
+	return OtherTopic
+}
+
+// SetLagSource consumer lag 를 제공하는 함수 등록
+func SetLagSource(fn func() int64) {
+	lagSource.Store(fn)
+}
+
+// Handler /metrics 핸들러
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
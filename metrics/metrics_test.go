@@ -0,0 +1,28 @@
+package metrics
+
+import "testing"
+
+func TestTopicMapsUnconfiguredTopicsToOther(t *testing.T) {
+	SetTopics([]string{"users", "Orders", ""})
+	defer SetTopics(nil)
+
+	cases := map[string]string{
+		"users":      "users",
+		"USERS":      "users",
+		"orders":     "Orders",
+		"random-123": OtherTopic,
+		"":           OtherTopic,
+	}
+	for in, want := range cases {
+		if got := Topic(in); got != want {
+			t.Errorf("Topic(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTopicBeforeSetTopics(t *testing.T) {
+	SetTopics(nil)
+	if got := Topic("users"); got != OtherTopic {
+		t.Fatalf("Topic = %q, want %q", got, OtherTopic)
+	}
+}
@@ -0,0 +1,213 @@
+package reload
+
+import (
+	"cache/config"
+	"cache/core"
+	"cache/core/event_broker"
+	_interface "cache/interface"
+	"cache/logger"
+	"cache/metrics"
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+
+	"go.uber.org/zap"
+)
+
+// safePaths 재시작 없이 적용할 수 있는 설정. 하위 경로 포함
+var safePaths = []string{
+	"logging.level",
+	"invalidation",
+	"event_broker.kafka.topics",
+	"metrics.topics",
+}
+
+// Reloader SIGHUP 이나 설정 파일 변경 시 실행 중 바꿔도 안전한 설정만 적용한다.
+// 그 외 항목이 바뀌었으면 아무것도 적용하지 않고 거부한다.
+type Reloader struct {
+	path      string
+	overrides map[string]interface{}
+	service   *core.CacheService
+	broker    _interface.IEventBroker
+	log       *zap.SugaredLogger
+
+	mu      sync.Mutex
+	current *config.Config
+}
+
+// NewReloader overrides 는 시작 시 사용한 CLI flag 값으로 reload 후에도 우선 적용된다
+func NewReloader(path string, overrides map[string]interface{}, current *config.Config, service *core.CacheService, broker _interface.IEventBroker) *Reloader {
+	return &Reloader{
+		path:      path,
+		overrides: overrides,
+		service:   service,
+		broker:    broker,
+		log:       logger.Logger,
+		current:   current,
+	}
+}
+
+// Watch ctx 가 끝날 때까지 SIGHUP 과 설정 파일 변경을 감지해 Reload
+func (r *Reloader) Watch(ctx context.Context) {
+	err := config.Watch(ctx, r.path, func() {
+		r.log.Infof("📝 Config file changed, reloading")
+		_ = r.Reload()
+	})
+	if err != nil {
+		r.log.Warnf("⚠️ Config file changes will not be reloaded, use SIGHUP: %v", err)
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, syscall.SIGHUP)
+	defer signal.Stop(sig)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sig:
+			r.log.Infof("📨 SIGHUP received, reloading config")
+			_ = r.Reload()
+		}
+	}
+}
+
+// Reload 설정을 다시 읽어 안전한 변경만 적용
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next, err := config.Load(r.path, r.overrides)
+	if err != nil {
+		r.log.Errorf("❌ Config reload failed: %v", err)
+		return err
+	}
+	if err := next.Validate(); err != nil {
+		r.log.Errorf("❌ Config reload rejected: %v", err)
+		return err
+	}
+
+	changed := diff(reflect.ValueOf(*r.current), reflect.ValueOf(*next), "")
+	if len(changed) == 0 {
+		r.log.Infof("🔄 Config reloaded, nothing changed")
+		return nil
+	}
+
+	var unsafe []string
+	for _, path := range changed {
+		if !isSafe(path) {
+			unsafe = append(unsafe, path)
+		}
+	}
+	if removed := missing(r.current.EventBroker.Kafka.Topics, next.EventBroker.Kafka.Topics); len(removed) > 0 {
+		unsafe = append(unsafe, fmt.Sprintf("event_broker.kafka.topics (removing %v)", removed))
+	} else if from, to := first(r.current.EventBroker.Kafka.Topics), first(next.EventBroker.Kafka.Topics); from != to {
+		// 첫 번째 topic 은 broker 가 발행에 쓰는 기본 topic 이고 실행 중에는 바꾸지 않는다
+		unsafe = append(unsafe, fmt.Sprintf("event_broker.kafka.topics (default topic %q to %q)", from, to))
+	}
+	if len(unsafe) > 0 {
+		err := fmt.Errorf("restart required to change: %s", strings.Join(unsafe, ", "))
+		r.log.Errorf("❌ Config reload rejected, %v", err)
+		return err
+	}
+
+	if err := r.apply(next); err != nil {
+		r.log.Errorf("❌ Config reload failed: %v", err)
+		return err
+	}
+	r.current = next
+	r.log.Infof("🔄 Config reloaded: %s", strings.Join(changed, ", "))
+	return nil
+}
+
+func (r *Reloader) apply(next *config.Config) error {
+	cur := r.current
+
+	// 실패할 수 있는 준비 작업을 먼저 끝내고 적용
+	var strategy _interface.IInvalidationStrategy
+	if !reflect.DeepEqual(cur.Invalidation, next.Invalidation) {
+		var err error
+		if strategy, err = core.NewInvalidationStrategy(next.Invalidation); err != nil {
+			return err
+		}
+	}
+	var topics _interface.ITopicManager
+	if !slices.Equal(cur.EventBroker.Kafka.Topics, next.EventBroker.Kafka.Topics) {
+		var ok bool
+		if topics, ok = event_broker.AsTopicManager(r.broker); !ok {
+			return fmt.Errorf("event broker does not support adding topics")
+		}
+	}
+
+	if next.Logging.Level != cur.Logging.Level {
+		if err := logger.SetLevel(next.Logging.Level); err != nil {
+			return err
+		}
+	}
+	if strategy != nil {
+		r.service.SetStrategy(strategy)
+	}
+	metrics.SetTopics(next.MetricTopics())
+	if topics != nil {
+		if err := topics.AddTopics(next.EventBroker.Kafka.Topics); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isSafe(path string) bool {
+	for _, p := range safePaths {
+		if path == p || strings.HasPrefix(path, p+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// diff 값이 다른 설정의 YAML 경로
+func diff(a reflect.Value, b reflect.Value, prefix string) []string {
+	if a.Kind() != reflect.Struct {
+		if reflect.DeepEqual(a.Interface(), b.Interface()) {
+			return nil
+		}
+		return []string{prefix}
+	}
+
+	var out []string
+	for i := 0; i < a.NumField(); i++ {
+		tag := a.Type().Field(i).Tag.Get("mapstructure")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		path := tag
+		if prefix != "" {
+			path = prefix + "." + tag
+		}
+		out = append(out, diff(a.Field(i), b.Field(i), path)...)
+	}
+	return out
+}
+
+func first(topics []string) string {
+	if len(topics) == 0 {
+		return ""
+	}
+	return topics[0]
+}
+
+func missing(before []string, after []string) []string {
+	var out []string
+	for _, t := range before {
+		if !slices.Contains(after, t) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,200 @@
+package reload
+
+import (
+	"cache/config"
+	"cache/core"
+	"cache/core/cache_adapter"
+	"cache/core/strategy"
+	_interface "cache/interface"
+	"cache/metrics"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type nopBroker struct{}
+
+func (nopBroker) Publish(context.Context, string, string) error              { return nil }
+func (nopBroker) Subscribe(context.Context, _interface.MessageHandler) error { return nil }
+func (nopBroker) Ping(context.Context) error                                 { return nil }
+func (nopBroker) ConsumerStatus() _interface.ConsumerStatus                  { return _interface.ConsumerStatus{} }
+func (nopBroker) Close() error                                               { return nil }
+
+// topicBroker 실행 중 topic 추가를 지원하는 broker
+type topicBroker struct {
+	nopBroker
+	added []string
+}
+
+func (b *topicBroker) AddTopics(topics []string) error {
+	b.added = topics
+	return nil
+}
+
+// newTestReloader 저장소 config.yaml 을 임시 경로에 복사해 Reloader 생성. edit 으로 파일을 고쳐 쓴다
+func newTestReloader(t *testing.T, broker _interface.IEventBroker) (*Reloader, func(old string, new string)) {
+	t.Helper()
+	data, err := os.ReadFile("../config.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	conf, err := config.Load(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := core.NewCacheService(
+		cache_adapter.NewMemoryAdapter(config.MemoryConfig{}),
+		strategy.NewVersionedKeyStrategy(conf.Invalidation),
+	)
+
+	edit := func(old string, new string) {
+		t.Helper()
+		cur, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(cur), old) {
+			t.Fatalf("config.yaml has no %q", old)
+		}
+		if err := os.WriteFile(path, []byte(strings.Replace(string(cur), old, new, 1)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return NewReloader(path, nil, conf, service, broker), edit
+}
+
+func TestReloadAppliesSafeChanges(t *testing.T) {
+	defer metrics.SetTopics(nil)
+	r, edit := newTestReloader(t, nopBroker{})
+
+	edit("level: info", "level: warn")
+	edit("  topic_ttls: {}\n\ngrpc:", "  topic_ttls:\n    users: 60\n\ngrpc:")
+	edit("  topics: []\n\nhealth:", "  topics: [\"orders\"]\n\nhealth:")
+
+	if err := r.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if r.current.Logging.Level != "warn" || r.current.Invalidation.TopicTTLs["users"] != 60 {
+		t.Fatalf("current = %+v / %+v", r.current.Logging, r.current.Invalidation)
+	}
+	for _, topic := range []string{"orders", "users"} {
+		if got := metrics.Topic(topic); got != topic {
+			t.Errorf("metrics.Topic(%q) = %q after reload", topic, got)
+		}
+	}
+}
+
+func TestReloadRejectsUnsafeChanges(t *testing.T) {
+	r, edit := newTestReloader(t, nopBroker{})
+	before := r.current
+
+	edit("level: info", "level: warn")
+	edit(`address: ":9000"`, `address: ":9001"`)
+
+	err := r.Reload()
+	if err == nil || !strings.Contains(err.Error(), "grpc.address") {
+		t.Fatalf("err = %v, want restart required for grpc.address", err)
+	}
+	// 일부만 적용하지 않는다
+	if r.current != before || r.current.Logging.Level != "info" {
+		t.Fatal("rejected reload changed the current config")
+	}
+}
+
+func TestReloadRejectsInvalidConfig(t *testing.T) {
+	r, edit := newTestReloader(t, nopBroker{})
+	edit("level: info", "level: verbose")
+
+	if err := r.Reload(); err == nil || !strings.Contains(err.Error(), "logging.level") {
+		t.Fatalf("err = %v, want validation error", err)
+	}
+}
+
+func TestReloadKafkaTopics(t *testing.T) {
+	broker := &topicBroker{}
+	r, edit := newTestReloader(t, broker)
+
+	edit(`      - "cache1"`, `      - "cache1"`+"\n"+`      - "cache2"`)
+	if err := r.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if len(broker.added) != 3 || broker.added[2] != "cache2" {
+		t.Fatalf("AddTopics(%v)", broker.added)
+	}
+
+	edit(`      - "cache2"`+"\n", "")
+	edit(`      - "cache1"`+"\n", "")
+	if err := r.Reload(); err == nil || !strings.Contains(err.Error(), "removing") {
+		t.Fatalf("err = %v, want removing a topic to be rejected", err)
+	}
+}
+
+func TestReloadRejectsDefaultTopicChange(t *testing.T) {
+	broker := &topicBroker{}
+	r, edit := newTestReloader(t, broker)
+
+	// 순서만 바꿔도 발행에 쓰는 기본 topic 이 달라진다
+	edit(`      - "cache0"`+"\n"+`      - "cache1"`, `      - "cache1"`+"\n"+`      - "cache0"`)
+	if err := r.Reload(); err == nil || !strings.Contains(err.Error(), "default topic") {
+		t.Fatalf("err = %v, want reordering topics to be rejected", err)
+	}
+	if broker.added != nil {
+		t.Fatalf("AddTopics(%v) called for a rejected reload", broker.added)
+	}
+}
+
+func TestWatchStopsWithContext(t *testing.T) {
+	r, edit := newTestReloader(t, nopBroker{})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		r.Watch(ctx)
+		close(done)
+	}()
+
+	// 파일 변경은 감시 중에 적용된다. 감시가 시작되기 전의 쓰기는 놓치므로 반영될 때까지 다시 쓴다
+	deadline := time.Now().Add(5 * time.Second)
+	edit("level: info", "level: debug")
+	for currentLevel(r) != "debug" {
+		if time.Now().After(deadline) {
+			t.Fatal("file change was not reloaded")
+		}
+		time.Sleep(50 * time.Millisecond)
+		edit("level: debug", "level: debug")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Watch did not return after cancel")
+	}
+	// 취소 후의 변경은 적용되지 않는다
+	edit("level: debug", "level: warn")
+	time.Sleep(200 * time.Millisecond)
+	if got := currentLevel(r); got != "debug" {
+		t.Fatalf("level = %q after cancel, want debug", got)
+	}
+}
+
+func currentLevel(r *Reloader) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current.Logging.Level
+}
+
+func TestReloadWithoutTopicManager(t *testing.T) {
+	r, edit := newTestReloader(t, nopBroker{})
+	edit(`      - "cache1"`, `      - "cache1"`+"\n"+`      - "cache2"`)
+
+	if err := r.Reload(); err == nil {
+		t.Fatal("expected error when the broker cannot add topics")
+	}
+}
@@ -0,0 +1,66 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// Header 클라이언트가 보낸 값을 그대로 쓰고, 없으면 새로 만들어 응답에도 실어 보낸다
+const Header = "X-Request-ID"
+
+// maxLen 이보다 긴 값은 로그/헤더 오염을 막기 위해 버리고 새로 발급
+const maxLen = 128
+
+type ctxKey struct{}
+
+// New 32자리 hex 랜덤 ID
+func New() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// WithContext ctx 에 request ID 를 담는다
+func WithContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxKey{}, id)
+}
+
+// From ctx 의 request ID. 없으면 빈 문자열
+func From(ctx context.Context) string {
+	id, _ := ctx.Value(ctxKey{}).(string)
+	return id
+}
+
+// Middleware 요청의 X-Request-ID 를 ctx 와 응답 헤더에 싣는다. 없거나 잘못된 값이면 새로 발급
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := Accept(r.Header.Get(Header))
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(WithContext(r.Context(), id)))
+	})
+}
+
+// Accept 클라이언트가 보낸 ID 가 유효하면 그대로, 아니면 새로 발급
+func Accept(id string) string {
+	if valid(id) {
+		return id
+	}
+	return New()
+}
+
+// valid 출력 가능한 ASCII 만 허용
+func valid(id string) bool {
+	if id == "" || len(id) > maxLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
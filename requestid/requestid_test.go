@@ -0,0 +1,54 @@
+package requestid
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAccept(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		keep bool
+	}{
+		{"client id", "abc-123", true},
+		{"empty", "", false},
+		{"too long", strings.Repeat("a", maxLen+1), false},
+		{"control characters", "abc\r\nX-Injected: 1", false},
+		{"spaces", "abc def", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Accept(tt.in)
+			if tt.keep && got != tt.in {
+				t.Fatalf("Accept(%q) = %q, want it kept", tt.in, got)
+			}
+			if !tt.keep && (got == tt.in || len(got) != 32) {
+				t.Fatalf("Accept(%q) = %q, want a new 32-char id", tt.in, got)
+			}
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var seen string
+	h := Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = From(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(Header, "from-client")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if seen != "from-client" || w.Header().Get(Header) != "from-client" {
+		t.Fatalf("ctx id = %q, header = %q, want from-client", seen, w.Header().Get(Header))
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if seen == "" || w.Header().Get(Header) != seen {
+		t.Fatalf("generated ctx id = %q, header = %q", seen, w.Header().Get(Header))
+	}
+}
@@ -0,0 +1,102 @@
+package tenant
+
+import (
+	"cache/apierror"
+	"cache/auth"
+	"cache/config"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	// ErrUnknownTenant 설정에 없는 tenant 이거나 tenant 를 지정하지 않음
+	ErrUnknownTenant = errors.New("unknown tenant")
+	// ErrTenantMismatch principal 에 고정된 tenant 와 헤더가 다름
+	ErrTenantMismatch = errors.New("tenant does not match authenticated principal")
+)
+
+// Resolver 인증 principal 또는 헤더로 요청의 tenant 를 결정
+type Resolver struct {
+	header      string
+	defaultID   string
+	tenants     map[string]*Tenant
+	byPrincipal map[string]*Tenant
+}
+
+// NewResolver tenancy 가 꺼져 있으면 nil 을 반환하며, nil Resolver 는 tenant 없이 통과시킨다
+func NewResolver(cfg config.TenancyConfig) *Resolver {
+	if !cfg.Enabled {
+		return nil
+	}
+	r := &Resolver{
+		header:      cfg.Header,
+		defaultID:   cfg.Default,
+		tenants:     make(map[string]*Tenant, len(cfg.Tenants)),
+		byPrincipal: make(map[string]*Tenant),
+	}
+	if r.header == "" {
+		r.header = "X-Tenant-ID"
+	}
+	for _, tc := range cfg.Tenants {
+		t := newTenant(tc)
+		r.tenants[t.ID] = t
+		for _, p := range tc.Principals {
+			r.byPrincipal[p] = t
+		}
+	}
+	return r
+}
+
+// Header tenant 를 지정하는 헤더 이름
+func (r *Resolver) Header() string {
+	return r.header
+}
+
+// Lookup ID 로 tenant 조회. 메시지에 실린 tenant 를 복원할 때 사용
+func (r *Resolver) Lookup(id string) (*Tenant, bool) {
+	if r == nil {
+		return nil, false
+	}
+	t, ok := r.tenants[id]
+	return t, ok
+}
+
+// Resolve principal 에 고정된 tenant 가 있으면 그것을, 없으면 requested(헤더 값) 또는 기본 tenant 를 사용
+func (r *Resolver) Resolve(principal string, requested string) (*Tenant, error) {
+	if t, ok := r.byPrincipal[principal]; ok && principal != "" {
+		if requested != "" && requested != t.ID {
+			return nil, ErrTenantMismatch
+		}
+		return t, nil
+	}
+	if requested == "" {
+		requested = r.defaultID
+	}
+	t, ok := r.tenants[requested]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownTenant, requested)
+	}
+	return t, nil
+}
+
+// Middleware 인증 미들웨어 뒤에 두어야 principal 로 tenant 를 결정할 수 있다.
+// 알 수 없는 tenant 는 400, principal 과 맞지 않는 tenant 는 403
+func (r *Resolver) Middleware(next http.Handler) http.Handler {
+	if r == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		p, _ := auth.PrincipalFrom(req.Context())
+		t, err := r.Resolve(p.Subject, req.Header.Get(r.header))
+		if errors.Is(err, ErrTenantMismatch) {
+			apierror.Write(w, req, http.StatusForbidden, apierror.CodeForbidden, err.Error())
+			return
+		}
+		if err != nil {
+			apierror.Write(w, req, http.StatusBadRequest, apierror.CodeInvalidRequest, err.Error())
+			return
+		}
+		next.ServeHTTP(w, req.WithContext(WithContext(req.Context(), t)))
+	})
+}
@@ -0,0 +1,150 @@
+package tenant
+
+import (
+	"cache/auth"
+	"cache/config"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func newTestResolver() *Resolver {
+	return NewResolver(config.TenancyConfig{
+		Enabled: true,
+		Default: "shared",
+		Tenants: []config.TenantConfig{
+			{ID: "shared"},
+			{ID: "acme", Principals: []string{"acme-svc"}},
+			{ID: "globex"},
+		},
+	})
+}
+
+func TestResolve(t *testing.T) {
+	r := newTestResolver()
+
+	tests := []struct {
+		name      string
+		principal string
+		requested string
+		want      string
+		err       error
+	}{
+		{name: "header", requested: "globex", want: "globex"},
+		{name: "default", want: "shared"},
+		{name: "pinned principal", principal: "acme-svc", want: "acme"},
+		{name: "pinned principal with same header", principal: "acme-svc", requested: "acme", want: "acme"},
+		{name: "pinned principal with other header", principal: "acme-svc", requested: "globex", err: ErrTenantMismatch},
+		{name: "unpinned principal", principal: "other-svc", requested: "globex", want: "globex"},
+		{name: "unknown", requested: "initech", err: ErrUnknownTenant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Resolve(tt.principal, tt.requested)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if tt.err == nil && got.ID != tt.want {
+				t.Fatalf("tenant = %q, want %q", got.ID, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveWithoutDefault(t *testing.T) {
+	r := NewResolver(config.TenancyConfig{Enabled: true, Tenants: []config.TenantConfig{{ID: "acme"}}})
+	if _, err := r.Resolve("", ""); !errors.Is(err, ErrUnknownTenant) {
+		t.Fatalf("err = %v, want ErrUnknownTenant", err)
+	}
+	if r.Header() != "X-Tenant-ID" {
+		t.Fatalf("Header = %q", r.Header())
+	}
+}
+
+func TestDisabledResolver(t *testing.T) {
+	r := NewResolver(config.TenancyConfig{Tenants: []config.TenantConfig{{ID: "acme"}}})
+	if r != nil {
+		t.Fatal("disabled tenancy should return nil")
+	}
+	if _, ok := r.Lookup("acme"); ok {
+		t.Fatal("nil resolver found a tenant")
+	}
+
+	called, hasTenant := false, false
+	h := r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		called = true
+		_, hasTenant = From(req.Context())
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	if !called || hasTenant {
+		t.Fatalf("called = %v, tenant = %v; want pass-through without tenant", called, hasTenant)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	guard, err := auth.NewGuard(context.Background(), config.AuthConfig{
+		APIKeys: []config.APIKeyConfig{{ID: "acme-svc", Key: "acme-key"}},
+		Audit:   config.AuditConfig{File: filepath.Join(t.TempDir(), "audit.log")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer guard.Close()
+
+	r := NewResolver(config.TenancyConfig{
+		Enabled: true,
+		Header:  "X-Team",
+		Tenants: []config.TenantConfig{{ID: "acme", Principals: []string{"acme-svc"}}, {ID: "globex"}},
+	})
+	var got string
+	h := guard.Middleware(r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		got = ID(req.Context())
+	})))
+
+	tests := []struct {
+		name   string
+		key    string
+		team   string
+		status int
+		tenant string
+	}{
+		{name: "pinned by principal", key: "acme-key", status: http.StatusOK, tenant: "acme"},
+		{name: "header conflicts with principal", key: "acme-key", team: "globex", status: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = ""
+			req := httptest.NewRequest(http.MethodGet, "/v1/cache/users/1", nil)
+			req.Header.Set("X-API-Key", tt.key)
+			if tt.team != "" {
+				req.Header.Set("X-Team", tt.team)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+			if w.Code != tt.status || got != tt.tenant {
+				t.Fatalf("status = %d, tenant = %q; want %d, %q", w.Code, got, tt.status, tt.tenant)
+			}
+		})
+	}
+
+	// 인증 없이 헤더만으로 tenant 지정, 없는 tenant 는 400
+	unauthenticated := r.Middleware(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		got = ID(req.Context())
+	}))
+	for team, status := range map[string]int{"globex": http.StatusOK, "initech": http.StatusBadRequest, "": http.StatusBadRequest} {
+		got = ""
+		req := httptest.NewRequest(http.MethodGet, "/v1/cache/users/1", nil)
+		req.Header.Set("X-Team", team)
+		w := httptest.NewRecorder()
+		unauthenticated.ServeHTTP(w, req)
+		if w.Code != status {
+			t.Errorf("team %q: status = %d, want %d", team, w.Code, status)
+		}
+		if status == http.StatusOK && got != team {
+			t.Errorf("team %q: tenant = %q", team, got)
+		}
+	}
+}
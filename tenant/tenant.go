@@ -0,0 +1,74 @@
+package tenant
+
+import (
+	"cache/config"
+	"context"
+	"strings"
+)
+
+// Tenant 요청이 속한 tenant. key prefix, 발행 topic, TTL, quota 를 tenant 별로 분리한다
+type Tenant struct {
+	ID         string
+	KeyPrefix  string
+	KafkaTopic string // 비어 있으면 broker 기본 topic
+	Quota      config.QuotaConfig
+
+	defaultTTL int
+	topicTTLs  map[string]int
+}
+
+func newTenant(cfg config.TenantConfig) *Tenant {
+	t := &Tenant{
+		ID:         cfg.ID,
+		KeyPrefix:  cfg.KeyPrefix,
+		KafkaTopic: cfg.KafkaTopic,
+		Quota:      cfg.Quota,
+		defaultTTL: cfg.DefaultTTLSeconds,
+		topicTTLs:  make(map[string]int, len(cfg.TopicTTLs)),
+	}
+	if t.KeyPrefix == "" {
+		t.KeyPrefix = cfg.ID + ":"
+	}
+	for topic, ttl := range cfg.TopicTTLs {
+		// viper 가 map key 를 소문자로 바꾸므로 대소문자 구분 없이 찾는다
+		t.topicTTLs[strings.ToLower(topic)] = ttl
+	}
+	return t
+}
+
+// Key strategy 가 만든 key 앞에 tenant prefix 를 붙인다
+func (t *Tenant) Key(key string) string {
+	return t.KeyPrefix + key
+}
+
+// TTL 요청 TTL 이 없을 때 tenant 의 topic/기본 TTL. 둘 다 없으면 0 을 반환해 전역 설정을 따르게 한다
+func (t *Tenant) TTL(topic string, baseTTL int) int {
+	if baseTTL > 0 {
+		return baseTTL
+	}
+	if ttl, ok := t.topicTTLs[strings.ToLower(topic)]; ok {
+		return ttl
+	}
+	return t.defaultTTL
+}
+
+type ctxKey struct{}
+
+// WithContext ctx 에 tenant 를 담는다
+func WithContext(ctx context.Context, t *Tenant) context.Context {
+	return context.WithValue(ctx, ctxKey{}, t)
+}
+
+// From ctx 의 tenant. tenancy 가 꺼져 있으면 false
+func From(ctx context.Context) (*Tenant, bool) {
+	t, ok := ctx.Value(ctxKey{}).(*Tenant)
+	return t, ok && t != nil
+}
+
+// ID ctx 의 tenant ID, 없으면 빈 문자열
+func ID(ctx context.Context) string {
+	if t, ok := From(ctx); ok {
+		return t.ID
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package tenant
+
+import (
+	"cache/config"
+	"context"
+	"testing"
+)
+
+func TestTenantKey(t *testing.T) {
+	tests := []struct {
+		cfg  config.TenantConfig
+		want string
+	}{
+		{config.TenantConfig{ID: "acme"}, "acme:users:v1:1"},
+		{config.TenantConfig{ID: "globex", KeyPrefix: "gx/"}, "gx/users:v1:1"},
+	}
+	for _, tt := range tests {
+		if got := newTenant(tt.cfg).Key("users:v1:1"); got != tt.want {
+			t.Errorf("Key = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestTenantTTL(t *testing.T) {
+	tn := newTenant(config.TenantConfig{
+		ID:                "acme",
+		DefaultTTLSeconds: 300,
+		TopicTTLs:         map[string]int{"sessions": 60},
+	})
+
+	tests := []struct {
+		topic string
+		base  int
+		want  int
+	}{
+		{"users", 30, 30},   // 요청 TTL 우선
+		{"Sessions", 0, 60}, // topic TTL 은 대소문자 구분 없음
+		{"users", 0, 300},   // tenant 기본 TTL
+	}
+	for _, tt := range tests {
+		if got := tn.TTL(tt.topic, tt.base); got != tt.want {
+			t.Errorf("TTL(%q, %d) = %d, want %d", tt.topic, tt.base, got, tt.want)
+		}
+	}
+	// 아무것도 없으면 0 으로 전역 설정을 따른다
+	if got := newTenant(config.TenantConfig{ID: "x"}).TTL("users", 0); got != 0 {
+		t.Fatalf("TTL = %d, want 0", got)
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := From(ctx); ok || ID(ctx) != "" {
+		t.Fatal("tenant found in empty context")
+	}
+	if _, ok := From(WithContext(ctx, nil)); ok {
+		t.Fatal("nil tenant reported as present")
+	}
+	ctx = WithContext(ctx, newTenant(config.TenantConfig{ID: "acme"}))
+	if ID(ctx) != "acme" {
+		t.Fatalf("ID = %q", ID(ctx))
+	}
+}
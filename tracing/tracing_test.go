@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"cache/config"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInitDisabledRegistersPropagator(t *testing.T) {
+	shutdown, err := Init(config.TracingConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	// 비활성화여도 traceparent 는 이어받아 다음 서비스로 전달한다
+	header := http.Header{}
+	header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(header))
+	out := http.Header{}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(out))
+	if out.Get("traceparent") != header.Get("traceparent") {
+		t.Fatalf("traceparent = %q, want %q", out.Get("traceparent"), header.Get("traceparent"))
+	}
+}
+
+func TestEnd(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	_, ok := tracer.Start(context.Background(), "ok")
+	End(ok, nil)
+	_, failed := tracer.Start(context.Background(), "failed")
+	End(failed, errors.New("redis down"))
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("ended spans = %d, want 2", len(spans))
+	}
+	if spans[0].Status().Code != codes.Unset || len(spans[0].Events()) != 0 {
+		t.Fatalf("ok span status = %v, events = %d", spans[0].Status(), len(spans[0].Events()))
+	}
+	if spans[1].Status().Code != codes.Error || spans[1].Status().Description != "redis down" || len(spans[1].Events()) != 1 {
+		t.Fatalf("failed span status = %v, events = %d", spans[1].Status(), len(spans[1].Events()))
+	}
+}